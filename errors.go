@@ -0,0 +1,14 @@
+package gerpc
+
+import (
+	"github.com/itsLeonB/gerpc/internal"
+	"github.com/itsLeonB/ungerr"
+)
+
+// FromStatus converts a gRPC status error back into an ungerr.AppError,
+// reversing the mapping done by NewErrorInterceptor. The error kind and
+// details are restored from the attached ErrorInfo and BadRequest details.
+// Statuses without them are mapped by code, and nil returns nil.
+func FromStatus(err error) ungerr.AppError {
+	return internal.FromStatus(err)
+}
@@ -1,13 +1,22 @@
 package gerpc
 
 import (
+	"context"
 	"net"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
+	"time"
 
 	"github.com/itsLeonB/ezutil/v2"
+	"github.com/itsLeonB/gerpc/internal"
+	"github.com/rotisserie/eris"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
 )
 
 type GrpcServer struct {
@@ -16,10 +25,26 @@ type GrpcServer struct {
 	opts            []grpc.ServerOption
 	registerSrvFunc func(*grpc.Server) error
 	shutdownFunc    func() error
+	shutdownTimeout time.Duration
+	inFlight        *internal.InFlightTracker
+	health          *health.Server
+	readinessChecks []readinessCheck
+	readiness       readinessState
+	tls             tlsFiles
+	certs           *certStore
+	tracerProvider  trace.TracerProvider
+	metrics         metricsEndpoint
+	interceptors    *InterceptorChain
 }
 
 func NewGrpcServer() *GrpcServer {
-	return &GrpcServer{}
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+
+	return &GrpcServer{
+		health:    healthServer,
+		readiness: readinessState{results: make(map[string]ReadinessCheckResult)},
+	}
 }
 
 func (s *GrpcServer) WithLogger(logger ezutil.Logger) *GrpcServer {
@@ -47,7 +72,103 @@ func (s *GrpcServer) WithShutdownFunc(shutdownFunc func() error) *GrpcServer {
 	return s
 }
 
+// WithShutdownTimeout bounds how long in-flight RPCs are drained on shutdown.
+// Once the timeout elapses the server is forcibly stopped. Zero waits indefinitely.
+func (s *GrpcServer) WithShutdownTimeout(timeout time.Duration) *GrpcServer {
+	s.shutdownTimeout = timeout
+	return s
+}
+
+// Run starts the server and blocks until SIGINT or SIGTERM is received.
+// Any error returned by RunContext is fatal.
 func (s *GrpcServer) Run() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := s.RunContext(ctx); err != nil {
+		s.logger.Fatalf("%v", err)
+	}
+}
+
+// RunContext starts the server and blocks until ctx is cancelled or serving fails.
+// The server is gracefully stopped and the shutdown func is run before returning.
+func (s *GrpcServer) RunContext(ctx context.Context) error {
+	s.validate()
+
+	opts, err := s.serverOpts()
+	if err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", s.address)
+	if err != nil {
+		return eris.Wrapf(err, "error listening to %s", s.address)
+	}
+
+	metricsListener, err := s.listenMetrics()
+	if err != nil {
+		_ = listener.Close()
+		return err
+	}
+
+	grpcServer := grpc.NewServer(opts...)
+	if err := s.registerSrvFunc(grpcServer); err != nil {
+		_ = listener.Close()
+		if metricsListener != nil {
+			_ = metricsListener.Close()
+		}
+		return eris.Wrap(err, "error registering services")
+	}
+	s.registerHealth(grpcServer)
+
+	s.markServing(grpcServer)
+	backgroundCtx, stopBackground := context.WithCancel(ctx)
+	defer stopBackground()
+	var background sync.WaitGroup
+	s.startReadinessChecks(backgroundCtx)
+	s.startTLSReload(backgroundCtx, &background)
+	// Metrics outlive ctx so the final scrape during the drain still succeeds
+	metricsCtx, stopMetrics := context.WithCancel(context.WithoutCancel(ctx))
+	defer stopMetrics()
+	s.startMetricsServer(metricsCtx, metricsListener, &background)
+
+	serveErr := make(chan error, 1)
+	go func() {
+		s.logger.Infof("server started at: %s", listener.Addr())
+		serveErr <- grpcServer.Serve(listener)
+	}()
+
+	select {
+	case <-ctx.Done():
+		s.logger.Info("shutting down server...")
+		// Report NOT_SERVING first so load balancers stop routing while draining
+		s.health.Shutdown()
+		stopBackground()
+		s.stop(grpcServer)
+		stopMetrics()
+		background.Wait()
+	case err := <-serveErr:
+		s.health.Shutdown()
+		// Serve only stops accepting, close the connections already accepted
+		// so no handler outlives the cleanup of its dependencies
+		grpcServer.Stop()
+		stopBackground()
+		stopMetrics()
+		background.Wait()
+		// cleanup errors are already logged, the serve error takes precedence
+		_ = s.cleanup()
+		return eris.Wrap(err, "failed to serve")
+	}
+
+	if err := s.cleanup(); err != nil {
+		return err
+	}
+
+	s.logger.Info("server successfully shut down")
+	return nil
+}
+
+func (s *GrpcServer) validate() {
 	if s.logger == nil {
 		panic("logger cannot be nil, call WithLogger")
 	}
@@ -60,34 +181,73 @@ func (s *GrpcServer) Run() {
 	if s.shutdownFunc == nil {
 		s.shutdownFunc = func() error { return nil }
 	}
+}
 
-	listener, err := net.Listen("tcp", s.address)
-	if err != nil {
-		s.logger.Fatalf("error listening to %s: %v", s.address, err)
+func (s *GrpcServer) serverOpts() ([]grpc.ServerOption, error) {
+	var opts []grpc.ServerOption
+	if s.metrics.address != "" {
+		// Outermost, so metrics reflect the status callers receive
+		opts = append(opts, s.metricsOpts()...)
+	}
+	if s.interceptors != nil {
+		opts = append(opts, s.interceptors.ServerOptions(s.logger)...)
 	}
+	opts = append(opts, s.opts...)
+	if s.tls.enabled() {
+		certs, err := newCertStore(s.tls)
+		if err != nil {
+			return nil, err
+		}
+		s.certs = certs
+		opts = append(opts, grpc.Creds(credentials.NewTLS(certs.tlsConfig())))
+	}
+	if s.tracerProvider != nil {
+		opts = append(opts, s.tracingOpts()...)
+	}
+	if s.shutdownTimeout > 0 {
+		s.inFlight = internal.NewInFlightTracker()
+		opts = append(opts,
+			grpc.ChainUnaryInterceptor(s.inFlight.Handle),
+			grpc.ChainStreamInterceptor(s.inFlight.HandleStream),
+		)
+	}
+	return opts, nil
+}
 
-	grpcServer := grpc.NewServer(s.opts...)
-	if err := s.registerSrvFunc(grpcServer); err != nil {
-		s.logger.Fatalf("error registering services: %v", err)
+// stop gracefully stops the server, falling back to a forced stop
+// when draining takes longer than the shutdown timeout
+func (s *GrpcServer) stop(grpcServer *grpc.Server) {
+	if s.shutdownTimeout <= 0 {
+		grpcServer.GracefulStop()
+		return
 	}
 
+	stopped := make(chan struct{})
 	go func() {
-		s.logger.Infof("server started at: %s", s.address)
-		if err := grpcServer.Serve(listener); err != nil {
-			s.logger.Fatalf("failed to serve: %v", err)
-		}
+		grpcServer.GracefulStop()
+		close(stopped)
 	}()
 
-	exit := make(chan os.Signal, 1)
-	signal.Notify(exit, os.Interrupt, syscall.SIGTERM)
-	<-exit
-	s.logger.Info("shutting down server...")
-	grpcServer.GracefulStop()
+	timer := time.NewTimer(s.shutdownTimeout)
+	defer timer.Stop()
 
+	select {
+	case <-stopped:
+	case <-timer.C:
+		s.logger.Warnf("graceful shutdown timed out after %v, forcing stop", s.shutdownTimeout)
+		for method, count := range s.inFlight.Snapshot() {
+			s.logger.Warnf("RPC still in flight: method=%s count=%d", method, count)
+		}
+		grpcServer.Stop()
+		<-stopped
+	}
+}
+
+func (s *GrpcServer) cleanup() error {
 	s.logger.Info("initating cleanup")
 	if err := s.shutdownFunc(); err != nil {
 		s.logger.Errorf("error during cleanup: %v", err)
+		return eris.Wrap(err, "error during cleanup")
 	}
-
-	s.logger.Info("server successfully shut down")
+	return nil
 }
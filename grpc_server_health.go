@@ -0,0 +1,45 @@
+package gerpc
+
+import (
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// SetServingStatus updates the health status reported for the given service.
+// The empty service name represents the overall server health.
+// Updates made after shutdown has begun are ignored.
+func (s *GrpcServer) SetServingStatus(service string, status grpc_health_v1.HealthCheckResponse_ServingStatus) {
+	s.health.SetServingStatus(service, status)
+}
+
+// registerHealth registers the built-in health service unless
+// the registerSrvFunc already provided its own implementation
+func (s *GrpcServer) registerHealth(grpcServer *grpc.Server) {
+	if _, ok := grpcServer.GetServiceInfo()[grpc_health_v1.Health_ServiceDesc.ServiceName]; ok {
+		s.logger.Warn("health service already registered, skipping built-in health service")
+		return
+	}
+	grpc_health_v1.RegisterHealthServer(grpcServer, s.health)
+}
+
+// markServing flags every registered service as SERVING. The server itself and
+// the readiness checks, including services sharing a check's name, stay
+// NOT_SERVING until their checks have passed once.
+func (s *GrpcServer) markServing(grpcServer *grpc.Server) {
+	s.readiness.mu.RLock()
+	defer s.readiness.mu.RUnlock()
+
+	checked := make(map[string]bool, len(s.readinessChecks))
+	for _, rc := range s.readinessChecks {
+		result, ok := s.readiness.results[rc.name]
+		s.health.SetServingStatus(rc.name, servingStatus(ok && result.Err == nil))
+		checked[rc.name] = true
+	}
+	for service := range grpcServer.GetServiceInfo() {
+		if service == grpc_health_v1.Health_ServiceDesc.ServiceName || checked[service] {
+			continue
+		}
+		s.health.SetServingStatus(service, grpc_health_v1.HealthCheckResponse_SERVING)
+	}
+	s.health.SetServingStatus("", servingStatus(s.allChecksPassing()))
+}
@@ -0,0 +1,15 @@
+package gerpc
+
+// WithDefaultInterceptors installs the request ID, recovery, logging, error and validation
+// interceptors in the order documented on InterceptorChain, for unary and stream RPCs.
+// They run before interceptors passed through WithOpts.
+func (s *GrpcServer) WithDefaultInterceptors() *GrpcServer {
+	return s.WithInterceptorChain(NewInterceptorChain())
+}
+
+// WithInterceptorChain installs the interceptors of chain, logging through the server logger.
+// They run before interceptors passed through WithOpts.
+func (s *GrpcServer) WithInterceptorChain(chain *InterceptorChain) *GrpcServer {
+	s.interceptors = chain
+	return s
+}
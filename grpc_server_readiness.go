@@ -0,0 +1,143 @@
+package gerpc
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// ReadinessCheckResult is the latest outcome of a readiness check.
+// A zero CheckedAt means the check has not run yet.
+type ReadinessCheckResult struct {
+	Name      string
+	Err       error
+	CheckedAt time.Time
+}
+
+type readinessCheck struct {
+	name     string
+	check    func(context.Context) error
+	interval time.Duration
+}
+
+type readinessState struct {
+	mu      sync.RWMutex
+	results map[string]ReadinessCheckResult
+}
+
+// WithReadinessCheck registers a dependency check probed every interval while the server runs.
+// The result drives the health status of the service with the given name,
+// and the overall server health is SERVING only once every check has passed,
+// and only while every check keeps passing.
+func (s *GrpcServer) WithReadinessCheck(name string, check func(context.Context) error, interval time.Duration) *GrpcServer {
+	if check == nil {
+		panic("readiness check cannot be nil")
+	}
+	if interval <= 0 {
+		panic("readiness check interval must be positive")
+	}
+	s.readinessChecks = append(s.readinessChecks, readinessCheck{name, check, interval})
+	return s
+}
+
+// ReadinessResults returns the latest result of every readiness check, sorted by name
+func (s *GrpcServer) ReadinessResults() []ReadinessCheckResult {
+	s.readiness.mu.RLock()
+	defer s.readiness.mu.RUnlock()
+
+	results := make([]ReadinessCheckResult, 0, len(s.readinessChecks))
+	for _, rc := range s.readinessChecks {
+		result, ok := s.readiness.results[rc.name]
+		if !ok {
+			result = ReadinessCheckResult{Name: rc.name}
+		}
+		results = append(results, result)
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].Name < results[j].Name })
+
+	return results
+}
+
+// Ready reports whether every readiness check passed on its latest run.
+// Checks that have not run yet count as not ready.
+func (s *GrpcServer) Ready() bool {
+	s.readiness.mu.RLock()
+	defer s.readiness.mu.RUnlock()
+	return s.allChecksPassing()
+}
+
+// startReadinessChecks probes every check until ctx is done.
+// Probes are not waited for on shutdown: a check ignoring its context must not
+// hold up the drain, and outcomes arriving after ctx is done are discarded.
+func (s *GrpcServer) startReadinessChecks(ctx context.Context) {
+	for _, rc := range s.readinessChecks {
+		go s.runReadinessCheck(ctx, rc)
+	}
+}
+
+func (s *GrpcServer) runReadinessCheck(ctx context.Context, rc readinessCheck) {
+	ticker := time.NewTicker(rc.interval)
+	defer ticker.Stop()
+
+	for {
+		s.probe(ctx, rc)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *GrpcServer) probe(ctx context.Context, rc readinessCheck) {
+	probeCtx, cancel := context.WithTimeout(ctx, rc.interval)
+	defer cancel()
+
+	err := rc.check(probeCtx)
+	if ctx.Err() != nil {
+		// shutting down, the outcome is meaningless
+		return
+	}
+
+	s.readiness.mu.Lock()
+	defer s.readiness.mu.Unlock()
+
+	previous := s.readiness.results[rc.name]
+	s.readiness.results[rc.name] = ReadinessCheckResult{
+		Name:      rc.name,
+		Err:       err,
+		CheckedAt: time.Now(),
+	}
+
+	switch {
+	case err != nil && previous.Err == nil:
+		s.logger.Warnf("readiness check %s failing: %v", rc.name, err)
+	case err == nil && previous.Err != nil:
+		s.logger.Infof("readiness check %s recovered", rc.name)
+	}
+
+	s.health.SetServingStatus(rc.name, servingStatus(err == nil))
+	s.health.SetServingStatus("", servingStatus(s.allChecksPassing()))
+}
+
+// allChecksPassing must be called with the readiness lock held
+func (s *GrpcServer) allChecksPassing() bool {
+	for _, rc := range s.readinessChecks {
+		result, ok := s.readiness.results[rc.name]
+		if !ok || result.Err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+func servingStatus(serving bool) grpc_health_v1.HealthCheckResponse_ServingStatus {
+	if serving {
+		return grpc_health_v1.HealthCheckResponse_SERVING
+	}
+	return grpc_health_v1.HealthCheckResponse_NOT_SERVING
+}
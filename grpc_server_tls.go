@@ -0,0 +1,185 @@
+package gerpc
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rotisserie/eris"
+)
+
+// minTLSVersion is the lowest TLS version accepted from clients
+const minTLSVersion = tls.VersionTLS12
+
+type tlsFiles struct {
+	certFile       string
+	keyFile        string
+	caFile         string
+	reloadInterval time.Duration
+}
+
+// WithTLS serves over TLS using the PEM encoded certificate and key files
+func (s *GrpcServer) WithTLS(certFile, keyFile string) *GrpcServer {
+	s.tls.certFile = certFile
+	s.tls.keyFile = keyFile
+	return s
+}
+
+// WithMutualTLS requires clients to present a certificate signed by the PEM encoded CA bundle.
+// It must be combined with WithTLS.
+func (s *GrpcServer) WithMutualTLS(caFile string) *GrpcServer {
+	s.tls.caFile = caFile
+	return s
+}
+
+// WithTLSReloadInterval checks the TLS files for changes every interval and swaps
+// in the new material without a restart. Zero, the default, disables reloading.
+func (s *GrpcServer) WithTLSReloadInterval(interval time.Duration) *GrpcServer {
+	s.tls.reloadInterval = interval
+	return s
+}
+
+func (f tlsFiles) enabled() bool {
+	return f.certFile != "" || f.keyFile != "" || f.caFile != ""
+}
+
+// certStore holds the active TLS material, swapped atomically on reload
+type certStore struct {
+	files       tlsFiles
+	cert        atomic.Pointer[tls.Certificate]
+	clientCAs   atomic.Pointer[x509.CertPool]
+	fingerprint string
+}
+
+// newCertStore loads the configured PEM material
+func newCertStore(files tlsFiles) (*certStore, error) {
+	if files.certFile == "" || files.keyFile == "" {
+		return nil, eris.New("TLS requires both a certificate and a key file, call WithTLS")
+	}
+
+	store := &certStore{files: files}
+	if err := store.load(); err != nil {
+		return nil, err
+	}
+
+	return store, nil
+}
+
+// tlsConfig returns a server config that always serves the currently loaded material
+func (cs *certStore) tlsConfig() *tls.Config {
+	config := &tls.Config{
+		MinVersion: minTLSVersion,
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return cs.cert.Load(), nil
+		},
+	}
+
+	if cs.files.caFile != "" {
+		config.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			clientConfig := config.Clone()
+			clientConfig.GetConfigForClient = nil
+			clientConfig.ClientCAs = cs.clientCAs.Load()
+			clientConfig.ClientAuth = tls.RequireAndVerifyClientCert
+			return clientConfig, nil
+		}
+	}
+
+	return config
+}
+
+// load parses every file and only swaps the material in once all of it is valid
+func (cs *certStore) load() error {
+	cs.fingerprint = cs.files.fingerprint()
+
+	cert, err := tls.LoadX509KeyPair(cs.files.certFile, cs.files.keyFile)
+	if err != nil {
+		return eris.Wrapf(err, "error loading TLS key pair from %s and %s", cs.files.certFile, cs.files.keyFile)
+	}
+
+	var pool *x509.CertPool
+	if cs.files.caFile != "" {
+		if pool, err = loadCertPool(cs.files.caFile); err != nil {
+			return err
+		}
+	}
+
+	cs.cert.Store(&cert)
+	cs.clientCAs.Store(pool)
+
+	return nil
+}
+
+// startTLSReload polls the TLS files until ctx is done
+func (s *GrpcServer) startTLSReload(ctx context.Context, wg *sync.WaitGroup) {
+	if s.certs == nil || s.tls.reloadInterval <= 0 {
+		return
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		ticker := time.NewTicker(s.tls.reloadInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.reloadTLS()
+			}
+		}
+	}()
+}
+
+// reloadTLS swaps in changed TLS material, keeping the previous material when it fails to load
+func (s *GrpcServer) reloadTLS() {
+	if s.tls.fingerprint() == s.certs.fingerprint {
+		return
+	}
+
+	if err := s.certs.load(); err != nil {
+		s.logger.Errorf("error reloading TLS certificates, keeping previous certificates: %v", err)
+		return
+	}
+
+	s.logger.Infof("TLS certificates reloaded from %s", s.tls.certFile)
+}
+
+// fingerprint identifies the current version of the files on disk.
+// Stat follows symlinks, so atomic symlink swaps such as Kubernetes secret volumes are detected.
+func (f tlsFiles) fingerprint() string {
+	var fingerprint string
+	for _, file := range []string{f.certFile, f.keyFile, f.caFile} {
+		if file == "" {
+			continue
+		}
+		info, err := os.Stat(file)
+		if err != nil {
+			fingerprint += file + ":missing;"
+			continue
+		}
+		fingerprint += fmt.Sprintf("%s:%d:%d;", file, info.ModTime().UnixNano(), info.Size())
+	}
+	return fingerprint
+}
+
+func loadCertPool(caFile string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, eris.Wrapf(err, "error reading CA bundle %s", caFile)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, eris.Errorf("no valid certificates found in CA bundle %s", caFile)
+	}
+
+	return pool, nil
+}
@@ -0,0 +1,36 @@
+package gerpc
+
+import (
+	"github.com/itsLeonB/gerpc/internal"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+)
+
+// RequestIDAttribute is the span attribute carrying the request ID.
+const RequestIDAttribute = internal.RequestIDAttribute
+
+// WithTracing starts an OpenTelemetry server span for every RPC, using tracerProvider
+// or the global provider when nil. Incoming trace context is extracted with the global propagator.
+// Spans end with the status returned by the interceptor chain, so chain the error interceptor
+// to have them agree with what callers see. The error interceptor also records panics and
+// masked internal errors on the span, and the request ID is added as an attribute.
+func (s *GrpcServer) WithTracing(tracerProvider trace.TracerProvider) *GrpcServer {
+	if tracerProvider == nil {
+		tracerProvider = otel.GetTracerProvider()
+	}
+	s.tracerProvider = tracerProvider
+	return s
+}
+
+// tracingOpts installs the OpenTelemetry stats handler, plus interceptors that run
+// after the user's own, so the request ID they assign is visible
+func (s *GrpcServer) tracingOpts() []grpc.ServerOption {
+	annotator := internal.NewSpanAnnotator()
+	return []grpc.ServerOption{
+		grpc.StatsHandler(otelgrpc.NewServerHandler(otelgrpc.WithTracerProvider(s.tracerProvider))),
+		grpc.ChainUnaryInterceptor(annotator.Handle),
+		grpc.ChainStreamInterceptor(annotator.HandleStream),
+	}
+}
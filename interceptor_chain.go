@@ -0,0 +1,184 @@
+package gerpc
+
+import (
+	"fmt"
+
+	"github.com/itsLeonB/ezutil/v2"
+	"google.golang.org/grpc"
+)
+
+// InterceptorStage names a position in an InterceptorChain.
+type InterceptorStage string
+
+const (
+	// StageRequestID assigns the request ID, outermost so every log line carries it.
+	StageRequestID InterceptorStage = "request_id"
+	// StageRecovery recovers panics raised by any later stage, including custom
+	// interceptors, and returns Internal. Errors pass through untouched.
+	StageRecovery InterceptorStage = "recovery"
+	// StageLogging writes the access log. It wraps the error stage so it logs
+	// the status callers receive rather than the raw handler error.
+	StageLogging InterceptorStage = "logging"
+	// StageErrors maps handler errors to gRPC statuses. It also recovers handler
+	// panics so the logging stage sees them as Internal.
+	StageErrors InterceptorStage = "errors"
+	// StageValidation validates requests, innermost so rejected requests are
+	// still logged and mapped like any other error.
+	StageValidation InterceptorStage = "validation"
+)
+
+// stageOrder lists the stages from outermost to innermost
+var stageOrder = []InterceptorStage{StageRequestID, StageRecovery, StageLogging, StageErrors, StageValidation}
+
+// stageInterceptors holds custom interceptors inserted next to a stage
+type stageInterceptors struct {
+	unary  []grpc.UnaryServerInterceptor
+	stream []grpc.StreamServerInterceptor
+}
+
+func (si *stageInterceptors) add(unary grpc.UnaryServerInterceptor, stream grpc.StreamServerInterceptor) {
+	if unary != nil {
+		si.unary = append(si.unary, unary)
+	}
+	if stream != nil {
+		si.stream = append(si.stream, stream)
+	}
+}
+
+// InterceptorChain builds the unary and stream interceptors of a server in a fixed order:
+// request ID, recovery, logging, errors (error mapping), then validation.
+// Custom interceptors can be inserted before or after any stage, and built-in stages disabled.
+type InterceptorChain struct {
+	requestIDOpts  []RequestIDInterceptorOption
+	loggingOpts    []LoggingInterceptorOption
+	errorOpts      []ErrorInterceptorOption
+	validationOpts []ValidationInterceptorOption
+	disabled       map[InterceptorStage]bool
+	before         map[InterceptorStage]*stageInterceptors
+	after          map[InterceptorStage]*stageInterceptors
+}
+
+func NewInterceptorChain() *InterceptorChain {
+	c := &InterceptorChain{
+		disabled: make(map[InterceptorStage]bool),
+		before:   make(map[InterceptorStage]*stageInterceptors),
+		after:    make(map[InterceptorStage]*stageInterceptors),
+	}
+	for _, stage := range stageOrder {
+		c.before[stage] = &stageInterceptors{}
+		c.after[stage] = &stageInterceptors{}
+	}
+	return c
+}
+
+// WithRequestIDOptions configures the interceptor of the request ID stage.
+func (c *InterceptorChain) WithRequestIDOptions(opts ...RequestIDInterceptorOption) *InterceptorChain {
+	c.requestIDOpts = append(c.requestIDOpts, opts...)
+	return c
+}
+
+// WithLoggingOptions configures the interceptor of the logging stage.
+func (c *InterceptorChain) WithLoggingOptions(opts ...LoggingInterceptorOption) *InterceptorChain {
+	c.loggingOpts = append(c.loggingOpts, opts...)
+	return c
+}
+
+// WithErrorOptions configures the interceptors of the recovery and errors stages.
+func (c *InterceptorChain) WithErrorOptions(opts ...ErrorInterceptorOption) *InterceptorChain {
+	c.errorOpts = append(c.errorOpts, opts...)
+	return c
+}
+
+// WithValidationOptions configures the interceptor of the validation stage.
+func (c *InterceptorChain) WithValidationOptions(opts ...ValidationInterceptorOption) *InterceptorChain {
+	c.validationOpts = append(c.validationOpts, opts...)
+	return c
+}
+
+// Without disables the built-in interceptors of the given stages.
+// Interceptors inserted around them are kept.
+func (c *InterceptorChain) Without(stages ...InterceptorStage) *InterceptorChain {
+	for _, stage := range stages {
+		c.mustHaveStage(stage)
+		c.disabled[stage] = true
+	}
+	return c
+}
+
+// InsertBefore runs the interceptors before, i.e. outside of, stage.
+// Either interceptor may be nil when it only applies to one kind of RPC.
+func (c *InterceptorChain) InsertBefore(stage InterceptorStage, unary grpc.UnaryServerInterceptor, stream grpc.StreamServerInterceptor) *InterceptorChain {
+	c.mustHaveStage(stage)
+	c.before[stage].add(unary, stream)
+	return c
+}
+
+// InsertAfter runs the interceptors after, i.e. inside of, stage.
+// Either interceptor may be nil when it only applies to one kind of RPC.
+func (c *InterceptorChain) InsertAfter(stage InterceptorStage, unary grpc.UnaryServerInterceptor, stream grpc.StreamServerInterceptor) *InterceptorChain {
+	c.mustHaveStage(stage)
+	c.after[stage].add(unary, stream)
+	return c
+}
+
+// UnaryInterceptors returns the unary interceptors in order, logging through logger.
+func (c *InterceptorChain) UnaryInterceptors(logger ezutil.Logger) []grpc.UnaryServerInterceptor {
+	var interceptors []grpc.UnaryServerInterceptor
+	for _, stage := range stageOrder {
+		interceptors = append(interceptors, c.before[stage].unary...)
+		if unary, _ := c.builtin(stage, logger); unary != nil {
+			interceptors = append(interceptors, unary)
+		}
+		interceptors = append(interceptors, c.after[stage].unary...)
+	}
+	return interceptors
+}
+
+// StreamInterceptors returns the stream interceptors in order, logging through logger.
+func (c *InterceptorChain) StreamInterceptors(logger ezutil.Logger) []grpc.StreamServerInterceptor {
+	var interceptors []grpc.StreamServerInterceptor
+	for _, stage := range stageOrder {
+		interceptors = append(interceptors, c.before[stage].stream...)
+		if _, stream := c.builtin(stage, logger); stream != nil {
+			interceptors = append(interceptors, stream)
+		}
+		interceptors = append(interceptors, c.after[stage].stream...)
+	}
+	return interceptors
+}
+
+// ServerOptions returns the chained interceptors as server options, for servers
+// not built with GrpcServer.
+func (c *InterceptorChain) ServerOptions(logger ezutil.Logger) []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(c.UnaryInterceptors(logger)...),
+		grpc.ChainStreamInterceptor(c.StreamInterceptors(logger)...),
+	}
+}
+
+// builtin returns the interceptors installed at stage, nil when disabled or none
+func (c *InterceptorChain) builtin(stage InterceptorStage, logger ezutil.Logger) (grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor) {
+	if c.disabled[stage] {
+		return nil, nil
+	}
+	switch stage {
+	case StageRequestID:
+		return NewRequestIDInterceptor(c.requestIDOpts...), NewStreamRequestIDInterceptor(c.requestIDOpts...)
+	case StageRecovery:
+		return NewRecoveryInterceptor(logger, c.errorOpts...), NewStreamRecoveryInterceptor(logger, c.errorOpts...)
+	case StageLogging:
+		return NewLoggingInterceptor(logger, c.loggingOpts...), NewStreamLoggingInterceptor(logger, c.loggingOpts...)
+	case StageErrors:
+		return NewErrorInterceptor(logger, c.errorOpts...), NewStreamErrorInterceptor(logger, c.errorOpts...)
+	case StageValidation:
+		return NewValidationInterceptor(c.validationOpts...), NewStreamValidationInterceptor(c.validationOpts...)
+	default:
+		return nil, nil
+	}
+}
+
+func (c *InterceptorChain) mustHaveStage(stage InterceptorStage) {
+	if _, ok := c.before[stage]; !ok {
+		panic(fmt.Sprintf("unknown interceptor stage %q", stage))
+	}
+}
@@ -1,21 +1,278 @@
 package gerpc
 
 import (
+	"context"
+	"log/slog"
+	"time"
+
+	"buf.build/go/protovalidate"
+	"github.com/go-playground/validator/v10"
 	"github.com/itsLeonB/ezutil/v2"
 	"github.com/itsLeonB/gerpc/internal"
+	"github.com/prometheus/client_golang/prometheus"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 )
 
+// ErrorMapper translates a handler error into an ungerr.AppError or a gRPC status error.
+// Returning nil, or any other kind of error, leaves the error to the next mapper.
+type ErrorMapper = internal.ErrorMapper
+
+// ErrorInterceptorOption configures NewErrorInterceptor, NewRecoveryInterceptor and their stream counterparts.
+type ErrorInterceptorOption = internal.ErrorInterceptorOption
+
+// WithErrorMapper registers mappers that are consulted in order, before the
+// built-in mapping, for errors that are not already an AppError or gRPC status.
+// Mappers typically match with errors.Is or errors.As.
+func WithErrorMapper(mappers ...ErrorMapper) ErrorInterceptorOption {
+	return internal.WithErrorMapper(mappers...)
+}
+
+// WithPanicMetrics counts panics recovered by the error interceptor in the
+// grpc_server_panics_total{method, kind} counter on registerer, or the default
+// registerer when nil. kind is one of nil_dereference, index_out_of_range,
+// slice_bounds_out_of_range, string_panic, runtime_error or unknown.
+func WithPanicMetrics(registerer prometheus.Registerer) ErrorInterceptorOption {
+	return internal.WithPanicMetrics(registerer)
+}
+
+// WithDevelopmentMode attaches a DebugInfo detail to the Internal status returned
+// for recovered panics and masked errors, holding the original message and the
+// panic or eris stack, e.g. for grpcurl. Off by default.
+// It exposes server internals to callers, so never enable it in production.
+func WithDevelopmentMode() ErrorInterceptorOption {
+	return internal.WithDevelopmentMode()
+}
+
+// WithDevelopmentModeFromEnv enables WithDevelopmentMode when the environment variable
+// name is set to a true value accepted by strconv.ParseBool, e.g. "1" or "true".
+// Unset, empty or invalid values leave it disabled.
+func WithDevelopmentModeFromEnv(name string) ErrorInterceptorOption {
+	return internal.WithDevelopmentModeFromEnv(name)
+}
+
 // NewErrorInterceptor creates an error handling interceptor for gRPC.
 // It captures errors and panics from gRPC handlers, converts them into
 // appropriate gRPC status codes with structured error messages.
-func NewErrorInterceptor(logger ezutil.Logger) grpc.UnaryServerInterceptor {
-	interceptor := internal.NewErrorInterceptor(logger)
+func NewErrorInterceptor(logger ezutil.Logger, opts ...ErrorInterceptorOption) grpc.UnaryServerInterceptor {
+	interceptor := internal.NewErrorInterceptor(logger, opts...)
+	return interceptor.Handle
+}
+
+// NewStreamErrorInterceptor is the streaming counterpart of NewErrorInterceptor.
+// Errors returned by the transport mid-stream keep their original status.
+func NewStreamErrorInterceptor(logger ezutil.Logger, opts ...ErrorInterceptorOption) grpc.StreamServerInterceptor {
+	interceptor := internal.NewStreamErrorInterceptor(logger, opts...)
+	return interceptor.HandleStream
+}
+
+// NewRecoveryInterceptor recovers panics anywhere further down the chain, logging
+// them and returning Internal like NewErrorInterceptor, while passing errors through
+// untouched. Install it outermost so panics in other interceptors are recovered too.
+func NewRecoveryInterceptor(logger ezutil.Logger, opts ...ErrorInterceptorOption) grpc.UnaryServerInterceptor {
+	interceptor := internal.NewRecoveryInterceptor(logger, opts...)
 	return interceptor.Handle
 }
 
+// NewStreamRecoveryInterceptor is the streaming counterpart of NewRecoveryInterceptor.
+func NewStreamRecoveryInterceptor(logger ezutil.Logger, opts ...ErrorInterceptorOption) grpc.StreamServerInterceptor {
+	interceptor := internal.NewStreamRecoveryInterceptor(logger, opts...)
+	return interceptor.HandleStream
+}
+
+// LoggingInterceptorOption configures NewLoggingInterceptor and NewStreamLoggingInterceptor.
+type LoggingInterceptorOption = internal.LoggingInterceptorOption
+
+// WithSkipMethods disables logging for the given full method names,
+// e.g. "/grpc.health.v1.Health/Check".
+func WithSkipMethods(methods ...string) LoggingInterceptorOption {
+	return internal.WithSkipMethods(methods...)
+}
+
+// WithSkipPrefixes disables logging for methods starting with any of the prefixes,
+// e.g. "/grpc.reflection.".
+func WithSkipPrefixes(prefixes ...string) LoggingInterceptorOption {
+	return internal.WithSkipPrefixes(prefixes...)
+}
+
+// WithCodeLevel logs calls ending with any of the given status codes at level.
+// By default OK is logged at Info and every other code at Error.
+func WithCodeLevel(level slog.Level, statusCodes ...codes.Code) LoggingInterceptorOption {
+	return internal.WithCodeLevel(level, statusCodes...)
+}
+
+// WithSlowThreshold escalates calls taking longer than threshold to at least Warn.
+func WithSlowThreshold(threshold time.Duration) LoggingInterceptorOption {
+	return internal.WithSlowThreshold(threshold)
+}
+
+// WithPayloadLogging logs unary request and response messages rendered with
+// protojson, truncated to maxBytes when positive. Fields marked with the
+// standard debug_redact option are always redacted. Off by default.
+func WithPayloadLogging(maxBytes int) LoggingInterceptorOption {
+	return internal.WithPayloadLogging(maxBytes)
+}
+
+// WithRedactedFields redacts fields from logged payloads by their dotted path
+// from the message root, e.g. "password" or "card.number".
+func WithRedactedFields(paths ...string) LoggingInterceptorOption {
+	return internal.WithRedactedFields(paths...)
+}
+
+// WithMetadataKeys adds the values of the given incoming metadata keys to access logs.
+// Only allowlisted keys are logged, so credentials in other headers stay out of logs.
+func WithMetadataKeys(keys ...string) LoggingInterceptorOption {
+	return internal.WithMetadataKeys(keys...)
+}
+
+// WithPrincipalFunc resolves the authenticated caller logged as identity.
+// When it returns an empty string the TLS client certificate common name is used.
+func WithPrincipalFunc(principalFunc func(context.Context) string) LoggingInterceptorOption {
+	return internal.WithPrincipalFunc(principalFunc)
+}
+
 // NewLoggingInterceptor logs incoming requests, responses, durations, and errors.
-func NewLoggingInterceptor(logger ezutil.Logger) grpc.UnaryServerInterceptor {
-	interceptor := internal.NewLoggingInterceptor(logger)
+func NewLoggingInterceptor(logger ezutil.Logger, opts ...LoggingInterceptorOption) grpc.UnaryServerInterceptor {
+	interceptor := internal.NewLoggingInterceptor(logger, opts...)
+	return interceptor.Handle
+}
+
+// NewStreamLoggingInterceptor logs streaming calls with their type, duration,
+// message counts, bytes transferred, and final status.
+func NewStreamLoggingInterceptor(logger ezutil.Logger, opts ...LoggingInterceptorOption) grpc.StreamServerInterceptor {
+	interceptor := internal.NewStreamLoggingInterceptor(logger, opts...)
+	return interceptor.HandleStream
+}
+
+// RequestIDKey is the metadata key carrying the request ID, read from incoming
+// metadata, echoed in response headers and forwarded to downstream calls.
+const RequestIDKey = internal.RequestIDKey
+
+// RequestIDInterceptorOption configures NewRequestIDInterceptor and NewStreamRequestIDInterceptor.
+type RequestIDInterceptorOption = internal.RequestIDInterceptorOption
+
+// WithRequestIDGenerator replaces the default UUID generator used when the
+// caller does not send a usable request ID.
+func WithRequestIDGenerator(generate func() string) RequestIDInterceptorOption {
+	return internal.WithRequestIDGenerator(generate)
+}
+
+// NewRequestIDInterceptor reads the request ID from the x-request-id metadata,
+// or generates one, stores it in the context and echoes it in the response headers.
+// Chain it before the logging and error interceptors so their logs include the ID.
+func NewRequestIDInterceptor(opts ...RequestIDInterceptorOption) grpc.UnaryServerInterceptor {
+	interceptor := internal.NewRequestIDInterceptor(opts...)
 	return interceptor.Handle
 }
+
+// NewStreamRequestIDInterceptor is the streaming counterpart of NewRequestIDInterceptor.
+func NewStreamRequestIDInterceptor(opts ...RequestIDInterceptorOption) grpc.StreamServerInterceptor {
+	interceptor := internal.NewStreamRequestIDInterceptor(opts...)
+	return interceptor.HandleStream
+}
+
+// NewClientRequestIDInterceptor forwards the request ID of the call context, as
+// assigned by NewRequestIDInterceptor, in the x-request-id metadata of outgoing calls,
+// so downstream services log the same ID. An x-request-id already set is kept.
+func NewClientRequestIDInterceptor() grpc.UnaryClientInterceptor {
+	return internal.ClientRequestIDInterceptor
+}
+
+// NewStreamClientRequestIDInterceptor is the streaming counterpart of NewClientRequestIDInterceptor.
+func NewStreamClientRequestIDInterceptor() grpc.StreamClientInterceptor {
+	return internal.StreamClientRequestIDInterceptor
+}
+
+// ContextWithRequestID returns a copy of ctx carrying the request ID,
+// e.g. to propagate it from a background job.
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return internal.ContextWithRequestID(ctx, id)
+}
+
+// RequestIDFromContext returns the request ID assigned by the request ID interceptor,
+// or an empty string when there is none.
+func RequestIDFromContext(ctx context.Context) string {
+	return internal.RequestIDFromContext(ctx)
+}
+
+// MetricsInterceptorOption configures NewMetricsInterceptor and NewStreamMetricsInterceptor.
+type MetricsInterceptorOption = internal.MetricsInterceptorOption
+
+// WithLatencyBuckets sets the buckets of the grpc_server_handling_seconds histogram.
+// Defaults to prometheus.DefBuckets.
+func WithLatencyBuckets(buckets ...float64) MetricsInterceptorOption {
+	return internal.WithLatencyBuckets(buckets...)
+}
+
+// WithMessageSizeBuckets sets the buckets of the grpc_server_msg_size_bytes histogram.
+// Defaults to exponential buckets from 64B to 4MiB.
+func WithMessageSizeBuckets(buckets ...float64) MetricsInterceptorOption {
+	return internal.WithMessageSizeBuckets(buckets...)
+}
+
+// NewMetricsInterceptor records Prometheus metrics for unary calls on registerer,
+// or the default registerer when nil:
+//   - grpc_server_handled_total{method, code}
+//   - grpc_server_handling_seconds{method}
+//   - grpc_server_in_flight{method}
+//   - grpc_server_msg_size_bytes{method, direction}
+//
+// Metrics already registered under these names are reused, so the unary and
+// stream interceptors can share a registerer. Reusing a histogram with different
+// buckets, or any other registration error, panics.
+func NewMetricsInterceptor(registerer prometheus.Registerer, opts ...MetricsInterceptorOption) grpc.UnaryServerInterceptor {
+	interceptor := internal.NewMetricsInterceptor(registerer, opts...)
+	return interceptor.Handle
+}
+
+// NewStreamMetricsInterceptor is the streaming counterpart of NewMetricsInterceptor,
+// observing the size of every message sent and received.
+func NewStreamMetricsInterceptor(registerer prometheus.Registerer, opts ...MetricsInterceptorOption) grpc.StreamServerInterceptor {
+	interceptor := internal.NewStreamMetricsInterceptor(registerer, opts...)
+	return interceptor.HandleStream
+}
+
+// ValidationInterceptorOption configures NewValidationInterceptor and NewStreamValidationInterceptor.
+type ValidationInterceptorOption = internal.ValidationInterceptorOption
+
+// WithProtoValidator replaces protovalidate.GlobalValidator for checking protovalidate rules.
+// Passing nil disables protovalidate rules.
+func WithProtoValidator(protoValidator protovalidate.Validator) ValidationInterceptorOption {
+	return internal.WithProtoValidator(protoValidator)
+}
+
+// WithStructValidator replaces the validator checking `validate` struct tags,
+// e.g. to register custom validations. Passing nil disables struct tags.
+func WithStructValidator(structValidator *validator.Validate) ValidationInterceptorOption {
+	return internal.WithStructValidator(structValidator)
+}
+
+// NewValidationInterceptor validates requests before the handler runs, in order with:
+//   - their ValidateAll() error or Validate() error method, e.g. from protoc-gen-validate
+//   - protovalidate rules on proto messages
+//   - go-playground/validator `validate` struct tags
+//
+// Invalid requests fail with InvalidArgument and a BadRequest detail listing the
+// field violations. AppErrors and gRPC statuses returned by Validate methods are kept.
+func NewValidationInterceptor(opts ...ValidationInterceptorOption) grpc.UnaryServerInterceptor {
+	interceptor := internal.NewValidationInterceptor(opts...)
+	return interceptor.Handle
+}
+
+// NewStreamValidationInterceptor is the streaming counterpart of NewValidationInterceptor,
+// validating every message received from the client.
+func NewStreamValidationInterceptor(opts ...ValidationInterceptorOption) grpc.StreamServerInterceptor {
+	interceptor := internal.NewStreamValidationInterceptor(opts...)
+	return interceptor.HandleStream
+}
+
+// NewClientErrorInterceptor converts errors returned by unary calls into
+// ungerr.AppError via FromStatus, so handlers can propagate them as is.
+func NewClientErrorInterceptor() grpc.UnaryClientInterceptor {
+	return internal.ClientErrorInterceptor
+}
+
+// NewStreamClientErrorInterceptor is the streaming counterpart of NewClientErrorInterceptor.
+func NewStreamClientErrorInterceptor() grpc.StreamClientInterceptor {
+	return internal.StreamClientErrorInterceptor
+}
@@ -0,0 +1,53 @@
+package internal
+
+import (
+	"github.com/itsLeonB/ungerr"
+	"google.golang.org/grpc/status"
+)
+
+// grpcStatusError is implemented by errors carrying a gRPC status
+type grpcStatusError interface {
+	error
+	GRPCStatus() *status.Status
+}
+
+// innermostError walks the whole chain of err, including every branch of
+// multi-errors such as errors.Join, and returns the deepest error matching match.
+// Ties at the same depth are resolved in favour of the first branch.
+// The returned depth is zero when err itself matched, and -1 when nothing did.
+func innermostError(err error, match func(error) bool) (int, error) {
+	var found error
+	foundDepth := -1
+
+	var walk func(e error, depth int)
+	walk = func(e error, depth int) {
+		if e == nil {
+			return
+		}
+		if depth > foundDepth && match(e) {
+			found, foundDepth = e, depth
+		}
+
+		switch wrapper := e.(type) {
+		case interface{ Unwrap() []error }:
+			for _, child := range wrapper.Unwrap() {
+				walk(child, depth+1)
+			}
+		case interface{ Unwrap() error }:
+			walk(wrapper.Unwrap(), depth+1)
+		}
+	}
+	walk(err, 0)
+
+	return foundDepth, found
+}
+
+// isTranslatedError reports whether err already describes its own gRPC status
+func isTranslatedError(err error) bool {
+	switch err.(type) {
+	case ungerr.AppError, grpcStatusError:
+		return true
+	default:
+		return false
+	}
+}
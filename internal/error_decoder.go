@@ -0,0 +1,183 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+
+	"github.com/itsLeonB/ungerr"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// FromStatus reverses appErrorStatus, rebuilding the AppError from the ErrorInfo
+// reason and details. Statuses without gerpc ErrorInfo are mapped by their code.
+// The returned error keeps the original status, so status.Code still reports its
+// code and propagating it from a handler sends the status back unchanged.
+func FromStatus(err error) ungerr.AppError {
+	if err == nil {
+		return nil
+	}
+	if appErr, ok := err.(ungerr.AppError); ok {
+		return appErr
+	}
+
+	st := status.Convert(err)
+
+	var errorInfo *errdetails.ErrorInfo
+	var badRequest *errdetails.BadRequest
+	for _, detail := range st.Details() {
+		switch detail := detail.(type) {
+		case *errdetails.ErrorInfo:
+			if detail.GetDomain() == ErrorDomain {
+				errorInfo = detail
+			}
+		case *errdetails.BadRequest:
+			badRequest = detail
+		}
+	}
+
+	if errorInfo == nil {
+		return statusAppError{appErrorFromCode(st, st.Message()), st}
+	}
+
+	details := decodeDetails(errorInfo.GetMetadata())
+	appErr := appErrorFromReason(errorInfo.GetReason(), details, badRequest != nil)
+	if appErr == nil {
+		return statusAppError{appErrorFromCode(st, details), st}
+	}
+	if badRequest != nil {
+		fve := fieldViolationError{appErr, badRequest.GetFieldViolations(), decodeFieldParams(errorInfo.GetMetadata())}
+		return statusFieldViolationError{fve, st}
+	}
+
+	return statusAppError{appErr, st}
+}
+
+func appErrorFromReason(reason string, details any, hasViolations bool) ungerr.AppError {
+	switch reason {
+	case "BAD_REQUEST":
+		return ungerr.BadRequestError(details)
+	case "UNAUTHORIZED":
+		return ungerr.UnauthorizedError(details)
+	case "FORBIDDEN":
+		return ungerr.ForbiddenError(details)
+	case "NOT_FOUND":
+		return ungerr.NotFoundError(details)
+	case "CONFLICT":
+		return ungerr.ConflictError(details)
+	case "UNPROCESSABLE_ENTITY":
+		// validation and unprocessable entity errors share a reason,
+		// only validation errors carry field violations
+		if hasViolations {
+			return ungerr.ValidationError(details)
+		}
+		return ungerr.UnprocessableEntityError(details)
+	case "INTERNAL_SERVER_ERROR":
+		return ungerr.InternalServerError()
+	default:
+		return nil
+	}
+}
+
+// appErrorFromCode maps a status code to its AppError. Codes without an equivalent,
+// e.g. Unavailable or DeadlineExceeded, become an InternalServerError described by the status.
+func appErrorFromCode(st *status.Status, details any) ungerr.AppError {
+	switch st.Code() {
+	case codes.InvalidArgument, codes.OutOfRange:
+		return ungerr.BadRequestError(details)
+	case codes.Unauthenticated:
+		return ungerr.UnauthorizedError(details)
+	case codes.PermissionDenied:
+		return ungerr.ForbiddenError(details)
+	case codes.NotFound:
+		return ungerr.NotFoundError(details)
+	case codes.AlreadyExists, codes.Aborted:
+		return ungerr.ConflictError(details)
+	case codes.FailedPrecondition:
+		return ungerr.UnprocessableEntityError(details)
+	case codes.Internal:
+		return ungerr.InternalServerError()
+	default:
+		return unmappedStatusError{ungerr.InternalServerError(), st}
+	}
+}
+
+// decodeFieldParams decodes the validation params stored under ErrorInfoFieldParamsKey
+func decodeFieldParams(metadata map[string]string) map[string]string {
+	encoded, ok := metadata[ErrorInfoFieldParamsKey]
+	if !ok {
+		return nil
+	}
+	var params map[string]string
+	if err := json.Unmarshal([]byte(encoded), &params); err != nil {
+		return nil
+	}
+	return params
+}
+
+func decodeDetails(metadata map[string]string) any {
+	if details, ok := metadata[ErrorInfoDetailsKey]; ok {
+		return details
+	}
+
+	encoded, ok := metadata[ErrorInfoDetailsJSONKey]
+	if !ok {
+		return nil
+	}
+
+	var stringsDetails []string
+	if err := json.Unmarshal([]byte(encoded), &stringsDetails); err == nil {
+		return stringsDetails
+	}
+
+	var details any
+	if err := json.Unmarshal([]byte(encoded), &details); err != nil {
+		return encoded
+	}
+
+	return details
+}
+
+// ClientErrorInterceptor converts errors returned by unary calls into AppErrors
+func ClientErrorInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if err := invoker(ctx, method, req, reply, cc, opts...); err != nil {
+		return FromStatus(err)
+	}
+	return nil
+}
+
+// StreamClientErrorInterceptor converts errors returned by streaming calls into AppErrors.
+// io.EOF is passed through untouched since it marks the normal end of a stream.
+func StreamClientErrorInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	stream, err := streamer(ctx, desc, cc, method, opts...)
+	if err != nil {
+		return nil, FromStatus(err)
+	}
+	return &errorClientStream{stream}, nil
+}
+
+type errorClientStream struct {
+	grpc.ClientStream
+}
+
+func (s *errorClientStream) SendMsg(m any) error {
+	return decodeStreamError(s.ClientStream.SendMsg(m))
+}
+
+func (s *errorClientStream) RecvMsg(m any) error {
+	return decodeStreamError(s.ClientStream.RecvMsg(m))
+}
+
+func (s *errorClientStream) CloseSend() error {
+	return decodeStreamError(s.ClientStream.CloseSend())
+}
+
+func decodeStreamError(err error) error {
+	if err == nil || err == io.EOF {
+		return err
+	}
+	return FromStatus(err)
+}
@@ -0,0 +1,190 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/itsLeonB/ungerr"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// ErrorDomain is the ErrorInfo domain attached to every AppError status
+const ErrorDomain = "gerpc"
+
+// ErrorInfoDetailsKey is the ErrorInfo metadata key holding string AppError details
+const ErrorInfoDetailsKey = "details"
+
+// ErrorInfoDetailsJSONKey is the ErrorInfo metadata key holding JSON encoded AppError details
+const ErrorInfoDetailsJSONKey = "details_json"
+
+// ErrorInfoFieldParamsKey is the ErrorInfo metadata key holding the validation params
+// of field violations as a JSON object by field, e.g. {"User.Name":"3"} for a failed min=3 rule
+const ErrorInfoFieldParamsKey = "field_params"
+
+// fieldViolationError is an AppError carrying validator field errors as BadRequest field violations,
+// along with the validation param of each field that has one
+type fieldViolationError struct {
+	ungerr.AppError
+	violations []*errdetails.BadRequest_FieldViolation
+	params     map[string]string
+}
+
+func (fve fieldViolationError) FieldViolations() []*errdetails.BadRequest_FieldViolation {
+	return fve.violations
+}
+
+func (fve fieldViolationError) FieldParams() map[string]string {
+	return fve.params
+}
+
+// debugAppError is an AppError carrying the masked error and its stack, for development mode
+type debugAppError struct {
+	ungerr.AppError
+	debugInfo *errdetails.DebugInfo
+}
+
+func (dae debugAppError) DebugInfo() *errdetails.DebugInfo {
+	return dae.debugInfo
+}
+
+// statusAppError is an AppError decoded from a status, keeping that status so
+// status.Code and re-propagation see the original code, message and details
+type statusAppError struct {
+	ungerr.AppError
+	st *status.Status
+}
+
+func (sae statusAppError) GrpcStatus() uint32 {
+	return uint32(sae.st.Code())
+}
+
+func (sae statusAppError) GRPCStatus() *status.Status {
+	return sae.st
+}
+
+// statusFieldViolationError is the statusAppError counterpart of fieldViolationError
+type statusFieldViolationError struct {
+	fieldViolationError
+	st *status.Status
+}
+
+func (sfe statusFieldViolationError) GrpcStatus() uint32 {
+	return uint32(sfe.st.Code())
+}
+
+func (sfe statusFieldViolationError) GRPCStatus() *status.Status {
+	return sfe.st
+}
+
+// unmappedStatusError is the InternalServerError decoded from a status without an
+// AppError equivalent, such as Unavailable or DeadlineExceeded, described by that status
+type unmappedStatusError struct {
+	ungerr.AppError
+	st *status.Status
+}
+
+func (use unmappedStatusError) Error() string {
+	return use.st.Err().Error()
+}
+
+func (use unmappedStatusError) Details() any {
+	return use.st.Message()
+}
+
+// newDebugAppError attaches a DebugInfo detail with the stack split into one entry per line
+func newDebugAppError(appErr ungerr.AppError, detail, stack string) ungerr.AppError {
+	return debugAppError{appErr, &errdetails.DebugInfo{
+		Detail:       detail,
+		StackEntries: strings.Split(strings.TrimSpace(stack), "\n"),
+	}}
+}
+
+// newFieldValidationError converts validator field errors into a ValidationError
+// that also carries one field violation per field error
+func newFieldValidationError(validationErrors validator.ValidationErrors) ungerr.AppError {
+	errors := make([]string, 0, len(validationErrors))
+	violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(validationErrors))
+	params := make(map[string]string)
+	for _, e := range validationErrors {
+		errors = append(errors, e.Error())
+
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       e.Namespace(),
+			Description: e.Error(),
+			Reason:      e.Tag(),
+		})
+		if e.Param() != "" {
+			params[e.Namespace()] = e.Param()
+		}
+	}
+
+	return fieldViolationError{ungerr.ValidationError(errors), violations, params}
+}
+
+// ErrorReason returns the stable ErrorInfo reason for an AppError, e.g. NOT_FOUND
+func ErrorReason(appErr ungerr.AppError) string {
+	reason := strings.ToUpper(http.StatusText(appErr.HttpStatus()))
+	return strings.ReplaceAll(reason, " ", "_")
+}
+
+// appErrorStatus builds the gRPC status for an AppError with structured error details attached
+func appErrorStatus(appErr ungerr.AppError) *status.Status {
+	// AppErrors decoded from a status are sent back unchanged
+	if se, ok := appErr.(grpcStatusError); ok {
+		return se.GRPCStatus()
+	}
+
+	st := status.New(codes.Code(appErr.GrpcStatus()), appErr.Error())
+
+	metadata := errorInfoMetadata(appErr.Details())
+	if fpe, ok := appErr.(interface{ FieldParams() map[string]string }); ok && len(fpe.FieldParams()) > 0 {
+		if encoded, err := json.Marshal(fpe.FieldParams()); err == nil {
+			if metadata == nil {
+				metadata = make(map[string]string)
+			}
+			metadata[ErrorInfoFieldParamsKey] = string(encoded)
+		}
+	}
+
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{
+		Reason:   ErrorReason(appErr),
+		Domain:   ErrorDomain,
+		Metadata: metadata,
+	}}
+	if fve, ok := appErr.(interface {
+		FieldViolations() []*errdetails.BadRequest_FieldViolation
+	}); ok {
+		details = append(details, &errdetails.BadRequest{FieldViolations: fve.FieldViolations()})
+	}
+	if dae, ok := appErr.(interface{ DebugInfo() *errdetails.DebugInfo }); ok {
+		details = append(details, dae.DebugInfo())
+	}
+
+	withDetails, err := st.WithDetails(details...)
+	if err != nil {
+		return st
+	}
+
+	return withDetails
+}
+
+func errorInfoMetadata(details any) map[string]string {
+	switch details := details.(type) {
+	case nil:
+		return nil
+	case string:
+		return map[string]string{ErrorInfoDetailsKey: details}
+	default:
+		encoded, err := json.Marshal(details)
+		if err != nil {
+			return map[string]string{ErrorInfoDetailsKey: fmt.Sprint(details)}
+		}
+		return map[string]string{ErrorInfoDetailsJSONKey: string(encoded)}
+	}
+}
@@ -3,15 +3,20 @@ package internal
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
+	"os"
 	"runtime"
 	"runtime/debug"
+	"strconv"
 	"strings"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/itsLeonB/ezutil/v2"
 	"github.com/itsLeonB/ungerr"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/rotisserie/eris"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -20,21 +25,86 @@ import (
 
 // errorInterceptor handles errors and panics in gRPC handlers
 type errorInterceptor struct {
-	logger ezutil.Logger
+	logger      ezutil.Logger
+	mappers     []ErrorMapper
+	panics      *prometheus.CounterVec
+	development bool
 }
 
-func NewErrorInterceptor(logger ezutil.Logger) Interceptor {
-	return &errorInterceptor{logger}
+// ErrorMapper translates a handler error into an ungerr.AppError or a gRPC status error.
+// Returning nil, or any other kind of error, leaves the error to the next mapper.
+type ErrorMapper func(err error) error
+
+// ErrorInterceptorOption configures the error interceptor
+type ErrorInterceptorOption func(*errorInterceptor)
+
+// WithErrorMapper appends mappers consulted in order before the built-in error mapping
+func WithErrorMapper(mappers ...ErrorMapper) ErrorInterceptorOption {
+	return func(ei *errorInterceptor) {
+		ei.mappers = append(ei.mappers, mappers...)
+	}
+}
+
+// WithPanicMetrics counts recovered panics by method and kind on registerer
+func WithPanicMetrics(registerer prometheus.Registerer) ErrorInterceptorOption {
+	return func(ei *errorInterceptor) {
+		if registerer == nil {
+			registerer = prometheus.DefaultRegisterer
+		}
+		ei.panics = register(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "grpc_server_panics_total",
+			Help: "Total number of panics recovered in gRPC handlers, by method and kind.",
+		}, []string{"method", "kind"}))
+	}
+}
+
+// WithDevelopmentMode returns the masked error or panic and its stack to clients
+func WithDevelopmentMode() ErrorInterceptorOption {
+	return func(ei *errorInterceptor) {
+		ei.development = true
+	}
+}
+
+// WithDevelopmentModeFromEnv enables development mode when the environment
+// variable name holds a true boolean, e.g. "1" or "true"
+func WithDevelopmentModeFromEnv(name string) ErrorInterceptorOption {
+	return func(ei *errorInterceptor) {
+		if enabled, err := strconv.ParseBool(os.Getenv(name)); err == nil && enabled {
+			ei.development = true
+		}
+	}
+}
+
+func NewErrorInterceptor(logger ezutil.Logger, opts ...ErrorInterceptorOption) Interceptor {
+	return newErrorInterceptor(logger, opts)
+}
+
+func NewStreamErrorInterceptor(logger ezutil.Logger, opts ...ErrorInterceptorOption) StreamInterceptor {
+	return newErrorInterceptor(logger, opts)
+}
+
+func newErrorInterceptor(logger ezutil.Logger, opts []ErrorInterceptorOption) *errorInterceptor {
+	ei := &errorInterceptor{logger: logger}
+	for _, opt := range opts {
+		opt(ei)
+	}
+	return ei
+}
+
+// callInfo describes the RPC being handled, for both unary and streaming calls
+type callInfo struct {
+	fullMethod string
+	server     any
 }
 
 // Handle is the main interceptor function that processes errors and panics
 func (ei *errorInterceptor) Handle(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	call := callInfo{info.FullMethod, info.Server}
+
 	// Panic recovery
 	defer func() {
 		if r := recover(); r != nil {
-			ei.handlePanic(r, ctx, info)
-			appError := ungerr.InternalServerError()
-			err = status.Error(codes.Code(appError.GrpcStatus()), appError.Error())
+			err = ei.handlePanic(r, ctx, call)
 		}
 	}()
 
@@ -43,39 +113,150 @@ func (ei *errorInterceptor) Handle(ctx context.Context, req any, info *grpc.Unar
 		return resp, nil
 	}
 
-	// Already a gRPC status → just return
-	if _, ok := status.FromError(err); ok {
-		return resp, err
+	return resp, ei.toStatusError(ctx, err, call)
+}
+
+// HandleStream applies the same panic recovery and error translation to streaming handlers
+func (ei *errorInterceptor) HandleStream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	call := callInfo{info.FullMethod, srv}
+	stream := &errorServerStream{ServerStream: ss}
+
+	// Panic recovery
+	defer func() {
+		if r := recover(); r != nil {
+			err = ei.handlePanic(r, ss.Context(), call)
+		}
+	}()
+
+	err = handler(srv, stream)
+	if err == nil {
+		return nil
+	}
+
+	// Errors from the transport mid-stream already carry the right status,
+	// even when the handler wrapped them before returning
+	for _, transportErr := range []error{stream.sendErr, stream.recvErr} {
+		if transportErr != nil && errors.Is(err, transportErr) {
+			return status.Convert(transportErr).Err()
+		}
+	}
+
+	return ei.toStatusError(ss.Context(), err, call)
+}
+
+// toStatusError converts a handler error into a gRPC status error
+func (ei *errorInterceptor) toStatusError(ctx context.Context, err error, call callInfo) error {
+	// Already an AppError or gRPC status, possibly wrapped → use the innermost one
+	if depth, translated := innermostError(err, isTranslatedError); translated != nil {
+		if depth > 0 && !logStructured(ei.logger, ctx, slog.LevelInfo, "gRPC method returned a wrapped error",
+			append(callFields(ctx, call), "error", err.Error())...) {
+			withRequestID(ctx, ei.logger).Infof("gRPC method %s returned a wrapped error: %v", call.fullMethod, err)
+		}
+		if appErr, ok := translated.(ungerr.AppError); ok {
+			return appErrorStatus(appErr).Err()
+		}
+		return translated.(grpcStatusError).GRPCStatus().Err()
 	}
 
-	// Check if it's already an AppError
-	if appErr, ok := err.(ungerr.AppError); ok {
-		return resp, status.Error(codes.Code(appErr.GrpcStatus()), appErr.Error())
+	// Custom mappers take precedence over the built-in mapping
+	if st, ok := ei.mapError(err); ok {
+		return st.Err()
+	}
+
+	// Cancellation and deadlines are not server faults, keep their own codes
+	if errors.Is(err, context.Canceled) {
+		ei.logContextError(ctx, err, call)
+		return status.Error(codes.Canceled, context.Canceled.Error())
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		ei.logContextError(ctx, err, call)
+		return status.Error(codes.DeadlineExceeded, context.DeadlineExceeded.Error())
 	}
 
 	// Handle other errors
-	appError := ei.constructAppError(err, info)
-	return resp, status.Error(codes.Code(appError.GrpcStatus()), appError.Error())
+	appError := ei.constructAppError(ctx, err, call)
+	return appErrorStatus(appError).Err()
+}
+
+// mapError returns the status of the first mapper that recognises err
+func (ei *errorInterceptor) mapError(err error) (*status.Status, bool) {
+	for _, mapper := range ei.mappers {
+		mapped := mapper(err)
+		if mapped == nil {
+			continue
+		}
+		if appErr, ok := mapped.(ungerr.AppError); ok {
+			return appErrorStatus(appErr), true
+		}
+		if st, ok := status.FromError(mapped); ok {
+			return st, true
+		}
+	}
+	return nil, false
+}
+
+// logContextError logs a cancellation or deadline error along with the cause of the request context
+func (ei *errorInterceptor) logContextError(ctx context.Context, err error, call callInfo) {
+	if logStructured(ei.logger, ctx, slog.LevelWarn, "gRPC method stopped",
+		append(callFields(ctx, call), "error", err.Error(), "cause", fmt.Sprint(context.Cause(ctx)))...) {
+		return
+	}
+	withRequestID(ctx, ei.logger).Warnf("gRPC method %s stopped: %v (cause: %v)", call.fullMethod, err, context.Cause(ctx))
+}
+
+// panicStatusError returns the status for a recovered panic
+func (ei *errorInterceptor) panicStatusError(r any, kind, stack string) error {
+	appErr := ei.maskedError(fmt.Sprintf("panic (%s): %v", kind, r), stack)
+	return appErrorStatus(appErr).Err()
+}
+
+// maskedError returns the Internal error sent in place of an unexpected failure,
+// carrying its detail and stack in development mode only
+func (ei *errorInterceptor) maskedError(detail, stack string) ungerr.AppError {
+	if ei.development {
+		return newDebugAppError(ungerr.InternalServerError(), detail, stack)
+	}
+	return ungerr.InternalServerError()
+}
+
+// errorServerStream records the last error returned by the transport in each direction.
+// Sending and receiving may happen on different goroutines, so each has its own field.
+type errorServerStream struct {
+	grpc.ServerStream
+	sendErr error
+	recvErr error
+}
+
+func (s *errorServerStream) SendMsg(m any) error {
+	err := s.ServerStream.SendMsg(m)
+	if err != nil {
+		s.sendErr = err
+	}
+	return err
+}
+
+func (s *errorServerStream) RecvMsg(m any) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err != nil && err != io.EOF {
+		s.recvErr = err
+	}
+	return err
 }
 
 // constructAppError converts various error types into AppError
-func (ei *errorInterceptor) constructAppError(err error, info *grpc.UnaryServerInfo) ungerr.AppError {
+func (ei *errorInterceptor) constructAppError(ctx context.Context, err error, call callInfo) ungerr.AppError {
 	// First, try to unwrap with eris to get the original error
 	originalErr := eris.Unwrap(err)
 	if originalErr == nil {
 		// No eris wrapping found - this means the error wasn't properly wrapped
 		// Log the location where the error occurred
-		return ei.logUnwrappedError(err, info)
+		return ei.logUnwrappedError(ctx, err, call)
 	}
 
 	// Handle known error types from eris-wrapped errors
 	switch originalErr := originalErr.(type) {
 	case validator.ValidationErrors:
-		var errors []string
-		for _, e := range originalErr {
-			errors = append(errors, e.Error())
-		}
-		return ungerr.ValidationError(errors)
+		return newFieldValidationError(originalErr)
 
 	case *json.SyntaxError:
 		return ungerr.BadRequestError("invalid json")
@@ -94,63 +275,110 @@ func (ei *errorInterceptor) constructAppError(err error, info *grpc.UnaryServerI
 
 		// Check for network-related errors that might be client errors
 		if strings.Contains(errStr, "connection reset by peer") ||
-			strings.Contains(errStr, "broken pipe") ||
-			strings.Contains(errStr, "context canceled") ||
-			strings.Contains(errStr, "context deadline exceeded") {
+			strings.Contains(errStr, "broken pipe") {
 			return ungerr.BadRequestError("connection error")
 		}
 
 		// This is an eris-wrapped error but not a known type
 		// Log with full stack trace and mask from user
-		return ei.logAndMaskError(err)
+		return ei.logAndMaskError(ctx, err, call)
 	}
 }
 
 // logUnwrappedError handles errors that weren't properly wrapped with eris
-func (ei *errorInterceptor) logUnwrappedError(err error, info *grpc.UnaryServerInfo) ungerr.AppError {
+func (ei *errorInterceptor) logUnwrappedError(ctx context.Context, err error, call callInfo) ungerr.AppError {
+	stack := fmt.Sprintf("%+v", err)
+	recordMaskedError(ctx, err, stack)
+
+	if logStructured(ei.logger, ctx, slog.LevelError, "unwrapped error detected, add eris.Wrap() or return ungerr.AppError",
+		append(callFields(ctx, call),
+			"classification", "unwrapped_error",
+			"error_type", fmt.Sprintf("%T", err),
+			"error", err.Error(),
+			"stack", stack,
+		)...,
+	) {
+		return ei.maskedError(err.Error(), stack)
+	}
+
 	// This function helps you identify where errors are being added without proper wrapping
-	ei.logger.Error("UNWRAPPED ERROR DETECTED - Please add eris.Wrap() or return ungerr.AppError")
-	ei.logger.Errorf("Error type: %T", err)
-	ei.logger.Errorf("Error message: %s", err.Error())
-	ei.logger.Errorf("gRPC method: %s", info.FullMethod)
-	ei.logger.Errorf("Server: %s", info.Server)
+	logger := withRequestID(ctx, ei.logger)
+	logger.Error("UNWRAPPED ERROR DETECTED - Please add eris.Wrap() or return ungerr.AppError")
+	logger.Errorf("Error type: %T", err)
+	logger.Errorf("Error message: %s", err.Error())
+	logger.Errorf("gRPC method: %s", call.fullMethod)
+	logger.Errorf("Server: %s", call.server)
 
-	ei.logger.Error("Stack trace from error location:")
-	ei.logger.Errorf("%+v", err)
+	logger.Error("Stack trace from error location:")
+	logger.Errorf("%s", stack)
 
 	// Return a masked error to the user
-	return ungerr.InternalServerError()
+	return ei.maskedError(err.Error(), stack)
 }
 
 // logAndMaskError handles eris-wrapped errors that need to be masked from users
-func (ei *errorInterceptor) logAndMaskError(err error) ungerr.AppError {
-	ei.logger.Errorf("Unhandled eris-wrapped error of type: %T", err)
-	ei.logger.Error("Full stack trace:")
-	ei.logger.Error(eris.ToString(err, true))
+func (ei *errorInterceptor) logAndMaskError(ctx context.Context, err error, call callInfo) ungerr.AppError {
+	stack := eris.ToString(err, true)
+	recordMaskedError(ctx, err, stack)
 
-	return ungerr.InternalServerError()
+	if logStructured(ei.logger, ctx, slog.LevelError, "unhandled error",
+		append(callFields(ctx, call),
+			"classification", "unhandled_error",
+			"error_type", fmt.Sprintf("%T", err),
+			"error", err.Error(),
+			"stack", stack,
+		)...,
+	) {
+		return ei.maskedError(err.Error(), stack)
+	}
+
+	logger := withRequestID(ctx, ei.logger)
+	logger.Errorf("Unhandled eris-wrapped error of type: %T", err)
+	logger.Error("Full stack trace:")
+	logger.Error(stack)
+
+	return ei.maskedError(err.Error(), stack)
 }
 
-// handlePanic recovers from panics and converts them to structured errors
-func (ei *errorInterceptor) handlePanic(r interface{}, ctx context.Context, info *grpc.UnaryServerInfo) {
+// handlePanic logs and counts recovered panics and converts them to a status error
+func (ei *errorInterceptor) handlePanic(r interface{}, ctx context.Context, call callInfo) error {
+	stack := string(debug.Stack())
+	kind := classifyPanic(r)
+	recordPanic(ctx, r, kind, stack)
+	if ei.panics != nil {
+		ei.panics.WithLabelValues(call.fullMethod, kind).Inc()
+	}
+
+	if logStructured(ei.logger, ctx, slog.LevelError, "panic recovered in gRPC handler",
+		append(callFields(ctx, call),
+			"classification", kind,
+			"panic_value", fmt.Sprint(r),
+			"panic_type", fmt.Sprintf("%T", r),
+			"stack", stack,
+		)...,
+	) {
+		return ei.panicStatusError(r, kind, stack)
+	}
+
 	// Log the panic with full stack trace
-	ei.logger.Error("PANIC RECOVERED in gRPC handler")
-	ei.logger.Errorf("gRPC method: %s", info.FullMethod)
-	ei.logger.Errorf("Server: %s", info.Server)
-	ei.logger.Errorf("Panic value: %v", r)
-	ei.logger.Errorf("Panic type: %T", r)
+	logger := withRequestID(ctx, ei.logger)
+	logger.Error("PANIC RECOVERED in gRPC handler")
+	logger.Errorf("gRPC method: %s", call.fullMethod)
+	logger.Errorf("Server: %s", call.server)
+	logger.Errorf("Panic value: %v", r)
+	logger.Errorf("Panic type: %T", r)
 
 	// Log context information if available
 	if deadline, ok := ctx.Deadline(); ok {
-		ei.logger.Errorf("Context deadline: %v", deadline)
+		logger.Errorf("Context deadline: %v", deadline)
 	}
 	if ctx.Err() != nil {
-		ei.logger.Errorf("Context error: %v", ctx.Err())
+		logger.Errorf("Context error: %v", ctx.Err())
 	}
 
 	// Print stack trace
-	ei.logger.Error("Stack trace:")
-	ei.logger.Error(string(debug.Stack()))
+	logger.Error("Stack trace:")
+	logger.Error(stack)
 
 	// Try to convert panic to a meaningful error
 	switch panicValue := r.(type) {
@@ -158,27 +386,75 @@ func (ei *errorInterceptor) handlePanic(r interface{}, ctx context.Context, info
 		// Handle string panics (often from panic("message"))
 		if strings.Contains(panicValue, "index out of range") ||
 			strings.Contains(panicValue, "slice bounds out of range") {
-			ei.logger.Error("Array/slice bounds panic detected")
+			logger.Error("Array/slice bounds panic detected")
 		} else if strings.Contains(panicValue, "nil pointer dereference") {
-			ei.logger.Error("Nil pointer dereference panic detected")
+			logger.Error("Nil pointer dereference panic detected")
 		} else {
-			ei.logger.Errorf("String panic: %s", panicValue)
+			logger.Errorf("String panic: %s", panicValue)
 		}
 
 	case runtime.Error:
 		// Handle runtime errors (nil pointer, index out of bounds, etc.)
-		ei.logger.Errorf("Runtime error panic: %v", panicValue)
+		logger.Errorf("Runtime error panic: %v", panicValue)
 		switch panicValue.Error() {
 		case "runtime error: invalid memory address or nil pointer dereference":
-			ei.logger.Error("Nil pointer dereference detected")
+			logger.Error("Nil pointer dereference detected")
 		case "runtime error: index out of range":
-			ei.logger.Error("Index out of range detected")
+			logger.Error("Index out of range detected")
 		case "runtime error: slice bounds out of range":
-			ei.logger.Error("Slice bounds out of range detected")
+			logger.Error("Slice bounds out of range detected")
 		}
 
 	default:
 		// Unknown panic type
-		ei.logger.Errorf("Unknown panic type: %T, value: %v", r, r)
+		logger.Errorf("Unknown panic type: %T, value: %v", r, r)
+	}
+
+	return ei.panicStatusError(r, kind, stack)
+}
+
+// callFields returns the structured fields describing the call and its context
+func callFields(ctx context.Context, call callInfo) []any {
+	fields := []any{
+		"method", call.fullMethod,
+		"server", fmt.Sprintf("%T", call.server),
+	}
+	if addr := peerAddress(ctx); addr != "" {
+		fields = append(fields, "peer", addr)
+	}
+	if id := requestID(ctx); id != "" {
+		fields = append(fields, "request_id", id)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		fields = append(fields, "deadline", deadline)
+	}
+	if ctx.Err() != nil {
+		fields = append(fields, "context_error", ctx.Err().Error())
+	}
+	return fields
+}
+
+// classifyPanic names the kind of panic recovered
+func classifyPanic(r interface{}) string {
+	switch panicValue := r.(type) {
+	case runtime.Error:
+		return classifyPanicMessage(panicValue.Error(), "runtime_error")
+	case string:
+		return classifyPanicMessage(panicValue, "string_panic")
+	default:
+		return "unknown"
+	}
+}
+
+func classifyPanicMessage(msg, fallback string) string {
+	switch {
+	case strings.Contains(msg, "nil pointer dereference"):
+		return "nil_dereference"
+	case strings.Contains(msg, "index out of range"):
+		return "index_out_of_range"
+	case strings.Contains(msg, "slice bounds out of range"):
+		return "slice_bounds_out_of_range"
+	default:
+		return fallback
 	}
 }
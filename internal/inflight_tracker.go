@@ -0,0 +1,61 @@
+package internal
+
+import (
+	"context"
+	"sync"
+
+	"google.golang.org/grpc"
+)
+
+// InFlightTracker counts the RPCs currently being handled, per method
+type InFlightTracker struct {
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func NewInFlightTracker() *InFlightTracker {
+	return &InFlightTracker{calls: make(map[string]int)}
+}
+
+// Handle tracks a unary RPC for the duration of the handler
+func (t *InFlightTracker) Handle(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	t.add(info.FullMethod)
+	defer t.done(info.FullMethod)
+
+	return handler(ctx, req)
+}
+
+// HandleStream tracks a streaming RPC for the duration of the handler
+func (t *InFlightTracker) HandleStream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	t.add(info.FullMethod)
+	defer t.done(info.FullMethod)
+
+	return handler(srv, ss)
+}
+
+// Snapshot returns a copy of the in-flight counts keyed by full method name
+func (t *InFlightTracker) Snapshot() map[string]int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	snapshot := make(map[string]int, len(t.calls))
+	for method, count := range t.calls {
+		snapshot[method] = count
+	}
+	return snapshot
+}
+
+func (t *InFlightTracker) add(method string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.calls[method]++
+}
+
+func (t *InFlightTracker) done(method string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.calls[method]--
+	if t.calls[method] <= 0 {
+		delete(t.calls, method)
+	}
+}
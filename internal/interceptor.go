@@ -9,3 +9,7 @@ import (
 type Interceptor interface {
 	Handle(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error)
 }
+
+type StreamInterceptor interface {
+	HandleStream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error
+}
@@ -0,0 +1,167 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/itsLeonB/ezutil/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// RequestIDKey is the metadata key carrying the request ID, both incoming and in response headers
+const RequestIDKey = "x-request-id"
+
+// requestID returns the request ID assigned by the request ID interceptor,
+// falling back to the one sent by the caller when the interceptor is not installed.
+// Caller IDs failing validRequestID are ignored so they never reach log lines or spans.
+func requestID(ctx context.Context) string {
+	if id := RequestIDFromContext(ctx); id != "" {
+		return id
+	}
+	if id := incomingRequestID(ctx); validRequestID(id) {
+		return id
+	}
+	return ""
+}
+
+// incomingRequestID returns the request ID sent by the caller, if any
+func incomingRequestID(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if values := md.Get(RequestIDKey); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// LeveledLogger emits a single log record at any level with alternating key/value fields.
+// A *slog.Logger satisfies it.
+type LeveledLogger interface {
+	Log(ctx context.Context, level slog.Level, msg string, args ...any)
+}
+
+// logStructured emits a record through logger when it is a LeveledLogger,
+// reporting whether it did so the caller can fall back to printf-style logging
+func logStructured(logger any, ctx context.Context, level slog.Level, msg string, args ...any) bool {
+	leveled, ok := logger.(LeveledLogger)
+	if !ok {
+		return false
+	}
+	leveled.Log(ctx, level, msg, args...)
+	return true
+}
+
+// peerAddress returns the address of the caller, if known
+func peerAddress(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return p.Addr.String()
+	}
+	return ""
+}
+
+// tlsCommonName returns the common name of the caller's TLS client certificate, if any
+func tlsCommonName(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return ""
+	}
+	if chains := info.State.VerifiedChains; len(chains) > 0 && len(chains[0]) > 0 {
+		return chains[0][0].Subject.CommonName
+	}
+	if certs := info.State.PeerCertificates; len(certs) > 0 {
+		return certs[0].Subject.CommonName
+	}
+	return ""
+}
+
+// incomingMetadata returns the comma-joined values of key from the incoming metadata
+func incomingMetadata(ctx context.Context, key string) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	return strings.Join(md.Get(key), ",")
+}
+
+// requestIDLogger prefixes every printf-style line with the request ID
+type requestIDLogger struct {
+	ezutil.Logger
+	prefix string
+	// formatPrefix is prefix escaped for use in a format string
+	formatPrefix string
+}
+
+// withRequestID returns logger tagging its lines with the request ID of ctx, if any
+func withRequestID(ctx context.Context, logger ezutil.Logger) ezutil.Logger {
+	id := requestID(ctx)
+	if id == "" {
+		return logger
+	}
+	prefix := fmt.Sprintf("[request_id=%s] ", id)
+	return &requestIDLogger{
+		Logger:       logger,
+		prefix:       prefix,
+		formatPrefix: strings.ReplaceAll(prefix, "%", "%%"),
+	}
+}
+
+func (l *requestIDLogger) Debug(args ...any) { l.Logger.Debug(append([]any{l.prefix}, args...)...) }
+func (l *requestIDLogger) Info(args ...any)  { l.Logger.Info(append([]any{l.prefix}, args...)...) }
+func (l *requestIDLogger) Warn(args ...any)  { l.Logger.Warn(append([]any{l.prefix}, args...)...) }
+func (l *requestIDLogger) Error(args ...any) { l.Logger.Error(append([]any{l.prefix}, args...)...) }
+func (l *requestIDLogger) Fatal(args ...any) { l.Logger.Fatal(append([]any{l.prefix}, args...)...) }
+
+func (l *requestIDLogger) Debugf(format string, args ...any) {
+	l.Logger.Debugf(l.formatPrefix+format, args...)
+}
+func (l *requestIDLogger) Infof(format string, args ...any) {
+	l.Logger.Infof(l.formatPrefix+format, args...)
+}
+func (l *requestIDLogger) Warnf(format string, args ...any) {
+	l.Logger.Warnf(l.formatPrefix+format, args...)
+}
+func (l *requestIDLogger) Errorf(format string, args ...any) {
+	l.Logger.Errorf(l.formatPrefix+format, args...)
+}
+func (l *requestIDLogger) Fatalf(format string, args ...any) {
+	l.Logger.Fatalf(l.formatPrefix+format, args...)
+}
+
+// fieldsSuffix renders key/value pairs as a printf format suffix and its args
+func fieldsSuffix(fields []any) (string, []any) {
+	var format strings.Builder
+	args := make([]any, 0, len(fields)/2)
+	for i := 0; i+1 < len(fields); i += 2 {
+		format.WriteString(fmt.Sprintf(" %s=%%v", fields[i]))
+		args = append(args, fields[i+1])
+	}
+	return format.String(), args
+}
+
+// accessFields returns the structured fields shared by every access log record
+func accessFields(ctx context.Context, method string, code codes.Code, elapsed time.Duration) []any {
+	fields := []any{
+		"method", method,
+		"code", code.String(),
+		"duration_ms", float64(elapsed.Microseconds()) / 1000,
+	}
+	if addr := peerAddress(ctx); addr != "" {
+		fields = append(fields, "peer", addr)
+	}
+	if id := requestID(ctx); id != "" {
+		fields = append(fields, "request_id", id)
+	}
+	return fields
+}
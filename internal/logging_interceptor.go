@@ -2,23 +2,127 @@ package internal
 
 import (
 	"context"
+	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/itsLeonB/ezutil/v2"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
 type loggingInterceptor struct {
-	logger ezutil.Logger
+	logger        ezutil.Logger
+	skipMethods   map[string]struct{}
+	skipPrefixes  []string
+	codeLevels    map[codes.Code]slog.Level
+	slowThreshold time.Duration
+	payload       *payloadRenderer
+	metadataKeys  []string
+	principalFunc func(context.Context) string
 }
 
-func NewLoggingInterceptor(logger ezutil.Logger) Interceptor {
-	return &loggingInterceptor{logger}
+// LoggingInterceptorOption configures the logging interceptor
+type LoggingInterceptorOption func(*loggingInterceptor)
+
+// WithSkipMethods disables logging for the given full method names
+func WithSkipMethods(methods ...string) LoggingInterceptorOption {
+	return func(li *loggingInterceptor) {
+		for _, method := range methods {
+			li.skipMethods[method] = struct{}{}
+		}
+	}
+}
+
+// WithSkipPrefixes disables logging for methods starting with any of the prefixes
+func WithSkipPrefixes(prefixes ...string) LoggingInterceptorOption {
+	return func(li *loggingInterceptor) {
+		li.skipPrefixes = append(li.skipPrefixes, prefixes...)
+	}
+}
+
+// WithCodeLevel logs calls ending with any of the status codes at level
+func WithCodeLevel(level slog.Level, statusCodes ...codes.Code) LoggingInterceptorOption {
+	return func(li *loggingInterceptor) {
+		for _, code := range statusCodes {
+			li.codeLevels[code] = level
+		}
+	}
+}
+
+// WithSlowThreshold logs calls taking longer than threshold at Warn or above
+func WithSlowThreshold(threshold time.Duration) LoggingInterceptorOption {
+	return func(li *loggingInterceptor) {
+		li.slowThreshold = threshold
+	}
+}
+
+// WithPayloadLogging logs unary request and response messages as JSON,
+// truncated to maxBytes when positive
+func WithPayloadLogging(maxBytes int) LoggingInterceptorOption {
+	return func(li *loggingInterceptor) {
+		if li.payload == nil {
+			li.payload = newPayloadRenderer(maxBytes)
+			return
+		}
+		li.payload.maxBytes = maxBytes
+	}
+}
+
+// WithRedactedFields redacts the fields at the given dotted paths from logged payloads
+func WithRedactedFields(paths ...string) LoggingInterceptorOption {
+	return func(li *loggingInterceptor) {
+		if li.payload == nil {
+			li.payload = newPayloadRenderer(0)
+		}
+		for _, path := range paths {
+			li.payload.redactPaths[path] = struct{}{}
+		}
+	}
+}
+
+// WithMetadataKeys logs the values of the given incoming metadata keys
+func WithMetadataKeys(keys ...string) LoggingInterceptorOption {
+	return func(li *loggingInterceptor) {
+		for _, key := range keys {
+			li.metadataKeys = append(li.metadataKeys, strings.ToLower(key))
+		}
+	}
+}
+
+// WithPrincipalFunc resolves the authenticated caller logged as identity,
+// taking precedence over the common name of the TLS client certificate
+func WithPrincipalFunc(principalFunc func(context.Context) string) LoggingInterceptorOption {
+	return func(li *loggingInterceptor) {
+		li.principalFunc = principalFunc
+	}
+}
+
+func NewLoggingInterceptor(logger ezutil.Logger, opts ...LoggingInterceptorOption) Interceptor {
+	return newLoggingInterceptor(logger, opts)
+}
+
+func newLoggingInterceptor(logger ezutil.Logger, opts []LoggingInterceptorOption) *loggingInterceptor {
+	li := &loggingInterceptor{
+		logger:      logger,
+		skipMethods: make(map[string]struct{}),
+		codeLevels:  make(map[codes.Code]slog.Level),
+	}
+	for _, opt := range opts {
+		opt(li)
+	}
+	return li
 }
 
 func (li *loggingInterceptor) Handle(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	if li.skip(info.FullMethod) {
+		return handler(ctx, req)
+	}
+
 	start := time.Now()
+	caller := li.callerFields(ctx)
 
 	// Call handler
 	resp, err = handler(ctx, req)
@@ -28,23 +132,291 @@ func (li *loggingInterceptor) Handle(ctx context.Context, req any, info *grpc.Un
 
 	// Extract gRPC status code (if error)
 	st, _ := status.FromError(err)
+	level, slow := li.level(st.Code(), elapsed)
+
+	extra := appendSize(caller, "request_bytes", req)
+	if err == nil {
+		extra = appendSize(extra, "response_bytes", resp)
+	}
+	extra = append(extra, li.payloadFields(req, resp, err)...)
 
+	fields := append(accessFields(ctx, info.FullMethod, st.Code(), elapsed), extra...)
+	if li.logStructured(ctx, level, slow, err, st, fields) {
+		return resp, err
+	}
+
+	extraFormat, extraArgs := fieldsSuffix(append(printfFields(ctx), extra...))
 	if err != nil {
-		li.logger.Errorf(
-			"[gRPC] method=%s duration=%v status=%s msg=%q err=%v",
-			info.FullMethod,
-			elapsed,
-			st.Code().String(),
-			st.Message(),
-			err,
+		li.logf(
+			level,
+			"[gRPC] method=%s duration=%v status=%s msg=%q err=%v"+slowSuffix(slow)+extraFormat,
+			append([]any{
+				info.FullMethod,
+				elapsed,
+				st.Code().String(),
+				st.Message(),
+				err,
+			}, extraArgs...)...,
 		)
 	} else {
-		li.logger.Infof(
-			"[gRPC] method=%s duration=%s status=OK",
-			info.FullMethod,
-			elapsed,
+		li.logf(
+			level,
+			"[gRPC] method=%s duration=%s status=OK"+slowSuffix(slow)+extraFormat,
+			append([]any{
+				info.FullMethod,
+				elapsed,
+			}, extraArgs...)...,
 		)
 	}
 
 	return resp, err
 }
+
+func NewStreamLoggingInterceptor(logger ezutil.Logger, opts ...LoggingInterceptorOption) StreamInterceptor {
+	return newLoggingInterceptor(logger, opts)
+}
+
+func (li *loggingInterceptor) HandleStream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if li.skip(info.FullMethod) {
+		return handler(srv, ss)
+	}
+
+	start := time.Now()
+	caller := li.callerFields(ss.Context())
+
+	// Call handler with a stream that counts messages
+	stream := &countingServerStream{ServerStream: ss}
+	err := handler(srv, stream)
+
+	// Duration
+	elapsed := time.Since(start)
+
+	// Extract gRPC status code (if error)
+	st, _ := status.FromError(err)
+	level, slow := li.level(st.Code(), elapsed)
+
+	fields := append(accessFields(ss.Context(), info.FullMethod, st.Code(), elapsed),
+		"stream_type", streamType(info),
+		"messages_sent", stream.sent,
+		"messages_received", stream.received,
+		"bytes_sent", stream.bytesSent,
+		"bytes_received", stream.bytesReceived,
+	)
+	fields = append(fields, caller...)
+	if li.logStructured(ss.Context(), level, slow, err, st, fields) {
+		return err
+	}
+
+	extraFormat, extraArgs := fieldsSuffix(append(printfFields(ss.Context()), caller...))
+	if err != nil {
+		li.logf(
+			level,
+			"[gRPC] method=%s type=%s duration=%v sent=%d received=%d bytes_sent=%d bytes_received=%d status=%s msg=%q err=%v"+slowSuffix(slow)+extraFormat,
+			append([]any{
+				info.FullMethod,
+				streamType(info),
+				elapsed,
+				stream.sent,
+				stream.received,
+				stream.bytesSent,
+				stream.bytesReceived,
+				st.Code().String(),
+				st.Message(),
+				err,
+			}, extraArgs...)...,
+		)
+	} else {
+		li.logf(
+			level,
+			"[gRPC] method=%s type=%s duration=%s sent=%d received=%d bytes_sent=%d bytes_received=%d status=OK"+slowSuffix(slow)+extraFormat,
+			append([]any{
+				info.FullMethod,
+				streamType(info),
+				elapsed,
+				stream.sent,
+				stream.received,
+				stream.bytesSent,
+				stream.bytesReceived,
+			}, extraArgs...)...,
+		)
+	}
+
+	return err
+}
+
+// skip reports whether logging is disabled for method
+func (li *loggingInterceptor) skip(method string) bool {
+	if _, ok := li.skipMethods[method]; ok {
+		return true
+	}
+	for _, prefix := range li.skipPrefixes {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// level picks the log level for a call: the configured level for its code,
+// else Info for OK and Error otherwise, escalated to Warn for slow calls
+func (li *loggingInterceptor) level(code codes.Code, elapsed time.Duration) (slog.Level, bool) {
+	level, ok := li.codeLevels[code]
+	if !ok {
+		level = slog.LevelError
+		if code == codes.OK {
+			level = slog.LevelInfo
+		}
+	}
+
+	slow := li.slowThreshold > 0 && elapsed > li.slowThreshold
+	if slow && level < slog.LevelWarn {
+		level = slog.LevelWarn
+	}
+
+	return level, slow
+}
+
+// logf logs through the printf-style method matching level
+func (li *loggingInterceptor) logf(level slog.Level, format string, args ...any) {
+	switch {
+	case level >= slog.LevelError:
+		li.logger.Errorf(format, args...)
+	case level >= slog.LevelWarn:
+		li.logger.Warnf(format, args...)
+	case level >= slog.LevelInfo:
+		li.logger.Infof(format, args...)
+	default:
+		li.logger.Debugf(format, args...)
+	}
+}
+
+// payloadFields renders the request and response when payload logging is enabled
+func (li *loggingInterceptor) payloadFields(req, resp any, err error) []any {
+	if li.payload == nil {
+		return nil
+	}
+	fields := []any{"request", li.payload.render(req)}
+	if err == nil {
+		fields = append(fields, "response", li.payload.render(resp))
+	}
+	return fields
+}
+
+// callerFields describes who is calling, captured before the handler runs
+// so the remaining deadline reflects the budget the call started with
+func (li *loggingInterceptor) callerFields(ctx context.Context) []any {
+	var fields []any
+	if identity := li.identity(ctx); identity != "" {
+		fields = append(fields, "identity", identity)
+	}
+	if userAgent := incomingMetadata(ctx, "user-agent"); userAgent != "" {
+		fields = append(fields, "user_agent", userAgent)
+	}
+	for _, key := range li.metadataKeys {
+		if value := incomingMetadata(ctx, key); value != "" {
+			fields = append(fields, "metadata."+key, value)
+		}
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		fields = append(fields, "deadline_remaining_ms", time.Until(deadline).Milliseconds())
+	}
+	return fields
+}
+
+// identity returns the authenticated caller, preferring the configured principal
+func (li *loggingInterceptor) identity(ctx context.Context) string {
+	if li.principalFunc != nil {
+		if principal := li.principalFunc(ctx); principal != "" {
+			return principal
+		}
+	}
+	return tlsCommonName(ctx)
+}
+
+// printfFields returns the peer address and request ID, which structured
+// records already carry through accessFields
+func printfFields(ctx context.Context) []any {
+	var fields []any
+	if addr := peerAddress(ctx); addr != "" {
+		fields = append(fields, "peer", addr)
+	}
+	if id := requestID(ctx); id != "" {
+		fields = append(fields, "request_id", id)
+	}
+	return fields
+}
+
+// appendSize appends the wire size of m under key when it is a proto message
+func appendSize(fields []any, key string, m any) []any {
+	if msg, ok := m.(proto.Message); ok {
+		return append(fields, key, proto.Size(msg))
+	}
+	return fields
+}
+
+func slowSuffix(slow bool) string {
+	if slow {
+		return " slow=true"
+	}
+	return ""
+}
+
+// logStructured emits the access record with typed fields when the logger supports it
+func (li *loggingInterceptor) logStructured(ctx context.Context, level slog.Level, slow bool, err error, st *status.Status, fields []any) bool {
+	if slow {
+		fields = append(fields, "slow", true)
+	}
+	if err != nil {
+		fields = append(fields, "status_message", st.Message(), "error", err.Error())
+		return logStructured(li.logger, ctx, level, "gRPC call failed", fields...)
+	}
+	return logStructured(li.logger, ctx, level, "gRPC call", fields...)
+}
+
+func streamType(info *grpc.StreamServerInfo) string {
+	switch {
+	case info.IsClientStream && info.IsServerStream:
+		return "bidi"
+	case info.IsClientStream:
+		return "client"
+	case info.IsServerStream:
+		return "server"
+	default:
+		return "unary"
+	}
+}
+
+// countingServerStream counts the messages and bytes that pass through a stream
+type countingServerStream struct {
+	grpc.ServerStream
+	sent          int
+	received      int
+	bytesSent     int
+	bytesReceived int
+}
+
+func (s *countingServerStream) SendMsg(m any) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil {
+		s.sent++
+		s.bytesSent += messageSize(m)
+	}
+	return err
+}
+
+func (s *countingServerStream) RecvMsg(m any) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		s.received++
+		s.bytesReceived += messageSize(m)
+	}
+	return err
+}
+
+// messageSize returns the wire size of proto messages, zero for anything else
+func messageSize(m any) int {
+	if msg, ok := m.(proto.Message); ok {
+		return proto.Size(msg)
+	}
+	return 0
+}
@@ -0,0 +1,200 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultMessageSizeBuckets spans messages from 64 bytes to 4MiB, the default gRPC receive limit
+var DefaultMessageSizeBuckets = prometheus.ExponentialBuckets(64, 4, 9)
+
+// metricsInterceptor records RPC counts, latencies, in-flight calls and message sizes
+type metricsInterceptor struct {
+	latencyBuckets     []float64
+	messageSizeBuckets []float64
+	handled            *prometheus.CounterVec
+	handlingSeconds    *prometheus.HistogramVec
+	inFlight           *prometheus.GaugeVec
+	messageSize        *prometheus.HistogramVec
+}
+
+// MetricsInterceptorOption configures the metrics interceptor
+type MetricsInterceptorOption func(*metricsInterceptor)
+
+// WithLatencyBuckets sets the buckets of the handling time histogram, in seconds
+func WithLatencyBuckets(buckets ...float64) MetricsInterceptorOption {
+	return func(mi *metricsInterceptor) {
+		mi.latencyBuckets = buckets
+	}
+}
+
+// WithMessageSizeBuckets sets the buckets of the message size histogram, in bytes
+func WithMessageSizeBuckets(buckets ...float64) MetricsInterceptorOption {
+	return func(mi *metricsInterceptor) {
+		mi.messageSizeBuckets = buckets
+	}
+}
+
+func NewMetricsInterceptor(registerer prometheus.Registerer, opts ...MetricsInterceptorOption) Interceptor {
+	return newMetricsInterceptor(registerer, opts)
+}
+
+func NewStreamMetricsInterceptor(registerer prometheus.Registerer, opts ...MetricsInterceptorOption) StreamInterceptor {
+	return newMetricsInterceptor(registerer, opts)
+}
+
+func newMetricsInterceptor(registerer prometheus.Registerer, opts []MetricsInterceptorOption) *metricsInterceptor {
+	mi := &metricsInterceptor{
+		latencyBuckets:     prometheus.DefBuckets,
+		messageSizeBuckets: DefaultMessageSizeBuckets,
+	}
+	for _, opt := range opts {
+		opt(mi)
+	}
+
+	if registerer == nil {
+		registerer = prometheus.DefaultRegisterer
+	}
+
+	mi.handled = register(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_server_handled_total",
+		Help: "Total number of RPCs completed on the server, by method and status code.",
+	}, []string{"method", "code"}))
+	mi.handlingSeconds = registerHistogram(registerer, prometheus.HistogramOpts{
+		Name:    "grpc_server_handling_seconds",
+		Help:    "Time taken by the server to handle RPCs, by method.",
+		Buckets: mi.latencyBuckets,
+	}, []string{"method"})
+	mi.inFlight = register(registerer, prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "grpc_server_in_flight",
+		Help: "Number of RPCs currently being handled, by method.",
+	}, []string{"method"}))
+	mi.messageSize = registerHistogram(registerer, prometheus.HistogramOpts{
+		Name:    "grpc_server_msg_size_bytes",
+		Help:    "Wire size of proto messages received and sent, by method and direction.",
+		Buckets: mi.messageSizeBuckets,
+	}, []string{"method", "direction"})
+
+	return mi
+}
+
+// register registers collector, reusing the one already registered under the same
+// name so the unary and stream interceptors share their metrics
+func register[T prometheus.Collector](registerer prometheus.Registerer, collector T) T {
+	err := registerer.Register(collector)
+	if err == nil {
+		return collector
+	}
+	var alreadyRegistered prometheus.AlreadyRegisteredError
+	if errors.As(err, &alreadyRegistered) {
+		if existing, ok := alreadyRegistered.ExistingCollector.(T); ok {
+			return existing
+		}
+	}
+	panic(err)
+}
+
+// histogramKey identifies a histogram registered on a registerer
+type histogramKey struct {
+	registerer prometheus.Registerer
+	name       string
+}
+
+// histogramBuckets holds the buckets each histogram was first registered with
+var histogramBuckets sync.Map
+
+// registerHistogram registers a histogram like register, panicking when it is
+// already registered with other buckets since the reused one would ignore them
+func registerHistogram(registerer prometheus.Registerer, opts prometheus.HistogramOpts, labels []string) *prometheus.HistogramVec {
+	if reflect.TypeOf(registerer).Comparable() {
+		key := histogramKey{registerer, opts.Name}
+		if buckets, loaded := histogramBuckets.LoadOrStore(key, opts.Buckets); loaded && !slices.Equal(buckets.([]float64), opts.Buckets) {
+			panic(fmt.Sprintf("histogram %s already registered with buckets %v, cannot use %v", opts.Name, buckets, opts.Buckets))
+		}
+	}
+	return register(registerer, prometheus.NewHistogramVec(opts, labels))
+}
+
+// Handle records the metrics of a unary RPC
+func (mi *metricsInterceptor) Handle(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	defer mi.finish(mi.start(info.FullMethod), &err)
+
+	mi.observeMessage(info.FullMethod, "received", req)
+	resp, err = handler(ctx, req)
+	if err == nil {
+		mi.observeMessage(info.FullMethod, "sent", resp)
+	}
+
+	return resp, err
+}
+
+// HandleStream records the metrics of a streaming RPC, observing every message
+func (mi *metricsInterceptor) HandleStream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer mi.finish(mi.start(info.FullMethod), &err)
+
+	return handler(srv, &metricsServerStream{ServerStream: ss, interceptor: mi, method: info.FullMethod})
+}
+
+// finish records the outcome of a call, deferred so panics recovered by an outer
+// interceptor are still counted, as Internal, before being propagated
+func (mi *metricsInterceptor) finish(done func(err error), err *error) {
+	if r := recover(); r != nil {
+		done(status.Error(codes.Internal, "panic"))
+		panic(r)
+	}
+	done(*err)
+}
+
+// start marks a call in flight and returns the func recording its outcome
+func (mi *metricsInterceptor) start(method string) func(err error) {
+	start := time.Now()
+	inFlight := mi.inFlight.WithLabelValues(method)
+	inFlight.Inc()
+
+	return func(err error) {
+		inFlight.Dec()
+		mi.handled.WithLabelValues(method, status.Code(err).String()).Inc()
+		mi.handlingSeconds.WithLabelValues(method).Observe(time.Since(start).Seconds())
+	}
+}
+
+// observeMessage records the wire size of proto messages, ignoring anything else
+func (mi *metricsInterceptor) observeMessage(method, direction string, m any) {
+	if msg, ok := m.(proto.Message); ok {
+		mi.messageSize.WithLabelValues(method, direction).Observe(float64(proto.Size(msg)))
+	}
+}
+
+// metricsServerStream observes the size of every message passing through a stream
+type metricsServerStream struct {
+	grpc.ServerStream
+	interceptor *metricsInterceptor
+	method      string
+}
+
+func (s *metricsServerStream) SendMsg(m any) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil {
+		s.interceptor.observeMessage(s.method, "sent", m)
+	}
+	return err
+}
+
+func (s *metricsServerStream) RecvMsg(m any) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		s.interceptor.observeMessage(s.method, "received", m)
+	}
+	return err
+}
@@ -0,0 +1,121 @@
+package internal
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// redactedValue replaces the value of sensitive string fields
+const redactedValue = "[REDACTED]"
+
+// truncatedSuffix marks payloads cut at the size limit
+const truncatedSuffix = "...(truncated)"
+
+// payloadRenderer renders proto messages as JSON for logging, with sensitive fields redacted.
+// Fields are sensitive when marked with the standard debug_redact field option,
+// or when their dotted path from the root message is configured, e.g. card.number.
+type payloadRenderer struct {
+	maxBytes    int
+	redactPaths map[string]struct{}
+}
+
+func newPayloadRenderer(maxBytes int) *payloadRenderer {
+	return &payloadRenderer{
+		maxBytes:    maxBytes,
+		redactPaths: make(map[string]struct{}),
+	}
+}
+
+// render returns the redacted and truncated JSON form of m
+func (pr *payloadRenderer) render(m any) string {
+	msg, ok := m.(proto.Message)
+	if !ok {
+		return fmt.Sprintf("<non-proto %T>", m)
+	}
+
+	redacted := proto.Clone(msg)
+	pr.redact(redacted.ProtoReflect(), "")
+
+	rendered, err := protojson.MarshalOptions{}.Marshal(redacted)
+	if err != nil {
+		return fmt.Sprintf("<unrenderable %T: %v>", m, err)
+	}
+
+	return pr.truncate(string(rendered))
+}
+
+func (pr *payloadRenderer) truncate(s string) string {
+	if pr.maxBytes <= 0 || len(s) <= pr.maxBytes {
+		return s
+	}
+	// Back off to a character boundary so the record stays valid UTF-8
+	end := pr.maxBytes
+	for end > 0 && !utf8.RuneStart(s[end]) {
+		end--
+	}
+	return s[:end] + truncatedSuffix
+}
+
+// redact clears sensitive fields in place, descending into nested, repeated and map messages
+func (pr *payloadRenderer) redact(msg protoreflect.Message, prefix string) {
+	msg.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		path := string(fd.Name())
+		if prefix != "" {
+			path = prefix + "." + path
+		}
+
+		if pr.sensitive(fd, path) {
+			redactField(msg, fd)
+			return true
+		}
+
+		switch {
+		case fd.IsMap():
+			if fd.MapValue().Message() != nil {
+				v.Map().Range(func(_ protoreflect.MapKey, value protoreflect.Value) bool {
+					pr.redact(value.Message(), path)
+					return true
+				})
+			}
+		case fd.IsList():
+			if fd.Message() != nil {
+				list := v.List()
+				for i := 0; i < list.Len(); i++ {
+					pr.redact(list.Get(i).Message(), path)
+				}
+			}
+		case fd.Message() != nil:
+			pr.redact(v.Message(), path)
+		}
+
+		return true
+	})
+}
+
+func (pr *payloadRenderer) sensitive(fd protoreflect.FieldDescriptor, path string) bool {
+	if _, ok := pr.redactPaths[path]; ok {
+		return true
+	}
+	opts, ok := fd.Options().(*descriptorpb.FieldOptions)
+	return ok && opts.GetDebugRedact()
+}
+
+// redactField masks singular string and bytes fields and clears anything else
+func redactField(msg protoreflect.Message, fd protoreflect.FieldDescriptor) {
+	if fd.Cardinality() != protoreflect.Repeated {
+		switch fd.Kind() {
+		case protoreflect.StringKind:
+			msg.Set(fd, protoreflect.ValueOfString(redactedValue))
+			return
+		case protoreflect.BytesKind:
+			msg.Set(fd, protoreflect.ValueOfBytes([]byte(redactedValue)))
+			return
+		}
+	}
+	msg.Clear(fd)
+}
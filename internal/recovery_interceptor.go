@@ -0,0 +1,44 @@
+package internal
+
+import (
+	"context"
+
+	"github.com/itsLeonB/ezutil/v2"
+	"google.golang.org/grpc"
+)
+
+// recoveryInterceptor recovers panics like the error interceptor but passes errors
+// through untouched, so it can run outside the interceptors that log and map them
+type recoveryInterceptor struct {
+	ei *errorInterceptor
+}
+
+func NewRecoveryInterceptor(logger ezutil.Logger, opts ...ErrorInterceptorOption) Interceptor {
+	return &recoveryInterceptor{newErrorInterceptor(logger, opts)}
+}
+
+func NewStreamRecoveryInterceptor(logger ezutil.Logger, opts ...ErrorInterceptorOption) StreamInterceptor {
+	return &recoveryInterceptor{newErrorInterceptor(logger, opts)}
+}
+
+// Handle converts a panic in the rest of the chain into an Internal status
+func (ri *recoveryInterceptor) Handle(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = ri.ei.handlePanic(r, ctx, callInfo{info.FullMethod, info.Server})
+		}
+	}()
+
+	return handler(ctx, req)
+}
+
+// HandleStream converts a panic in the rest of the chain into an Internal status
+func (ri *recoveryInterceptor) HandleStream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = ri.ei.handlePanic(r, ss.Context(), callInfo{info.FullMethod, srv})
+		}
+	}()
+
+	return handler(srv, ss)
+}
@@ -0,0 +1,130 @@
+package internal
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// maxRequestIDLength bounds incoming request IDs, longer ones are replaced
+const maxRequestIDLength = 128
+
+type requestIDContextKey struct{}
+
+// ContextWithRequestID returns a copy of ctx carrying the request ID
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey{}, id)
+}
+
+// RequestIDFromContext returns the request ID stored by the request ID interceptor, if any
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDContextKey{}).(string)
+	return id
+}
+
+// requestIDInterceptor assigns every call a request ID and echoes it in the response headers
+type requestIDInterceptor struct {
+	generate func() string
+}
+
+// RequestIDInterceptorOption configures the request ID interceptor
+type RequestIDInterceptorOption func(*requestIDInterceptor)
+
+// WithRequestIDGenerator replaces the default UUID generator for new request IDs
+func WithRequestIDGenerator(generate func() string) RequestIDInterceptorOption {
+	return func(ri *requestIDInterceptor) {
+		ri.generate = generate
+	}
+}
+
+func NewRequestIDInterceptor(opts ...RequestIDInterceptorOption) Interceptor {
+	return newRequestIDInterceptor(opts)
+}
+
+func NewStreamRequestIDInterceptor(opts ...RequestIDInterceptorOption) StreamInterceptor {
+	return newRequestIDInterceptor(opts)
+}
+
+func newRequestIDInterceptor(opts []RequestIDInterceptorOption) *requestIDInterceptor {
+	ri := &requestIDInterceptor{generate: uuid.NewString}
+	for _, opt := range opts {
+		opt(ri)
+	}
+	return ri
+}
+
+// Handle stores the request ID in the context and sets the response header
+func (ri *requestIDInterceptor) Handle(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	id := ri.requestID(ctx)
+	// Fails only outside a real server transport, the ID is still usable in the context
+	_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDKey, id))
+
+	return handler(ContextWithRequestID(ctx, id), req)
+}
+
+// HandleStream stores the request ID in the stream context and sets the response header
+func (ri *requestIDInterceptor) HandleStream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	id := ri.requestID(ss.Context())
+	_ = ss.SetHeader(metadata.Pairs(RequestIDKey, id))
+
+	return handler(srv, &requestIDServerStream{
+		ServerStream: ss,
+		ctx:          ContextWithRequestID(ss.Context(), id),
+	})
+}
+
+// requestID returns the caller's request ID when it is usable, else a new one
+func (ri *requestIDInterceptor) requestID(ctx context.Context) string {
+	if id := incomingRequestID(ctx); validRequestID(id) {
+		return id
+	}
+	return ri.generate()
+}
+
+// validRequestID accepts short printable ASCII IDs, keeping log lines intact
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// requestIDServerStream overrides the stream context with one carrying the request ID
+type requestIDServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *requestIDServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// ClientRequestIDInterceptor forwards the request ID of ctx to the called service
+func ClientRequestIDInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	return invoker(outgoingRequestID(ctx), method, req, reply, cc, opts...)
+}
+
+// StreamClientRequestIDInterceptor forwards the request ID of ctx to the called service
+func StreamClientRequestIDInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return streamer(outgoingRequestID(ctx), desc, cc, method, opts...)
+}
+
+// outgoingRequestID adds the request ID of ctx to the outgoing metadata,
+// leaving an ID already set by the caller in place
+func outgoingRequestID(ctx context.Context) context.Context {
+	id := RequestIDFromContext(ctx)
+	if id == "" {
+		return ctx
+	}
+	if md, ok := metadata.FromOutgoingContext(ctx); ok && len(md.Get(RequestIDKey)) > 0 {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, RequestIDKey, id)
+}
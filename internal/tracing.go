@@ -0,0 +1,63 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+)
+
+// RequestIDAttribute is the span attribute carrying the request ID
+const RequestIDAttribute = "request_id"
+
+// SpanAnnotator adds gerpc call attributes to the span started by the tracing stats handler
+type SpanAnnotator struct{}
+
+func NewSpanAnnotator() *SpanAnnotator {
+	return &SpanAnnotator{}
+}
+
+// Handle annotates the span of a unary RPC
+func (a *SpanAnnotator) Handle(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	annotateSpan(ctx)
+	return handler(ctx, req)
+}
+
+// HandleStream annotates the span of a streaming RPC
+func (a *SpanAnnotator) HandleStream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	annotateSpan(ss.Context())
+	return handler(srv, ss)
+}
+
+func annotateSpan(ctx context.Context) {
+	if id := requestID(ctx); id != "" {
+		trace.SpanFromContext(ctx).SetAttributes(attribute.String(RequestIDAttribute, id))
+	}
+}
+
+// recordMaskedError records an error hidden from the caller on the current span, along with its stack
+func recordMaskedError(ctx context.Context, err error, stack string) {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+	span.RecordError(err, trace.WithAttributes(
+		attribute.String("exception.stacktrace", stack),
+	))
+}
+
+// recordPanic adds a panic event to the current span
+func recordPanic(ctx context.Context, r any, kind, stack string) {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+	span.AddEvent("panic", trace.WithAttributes(
+		attribute.String("panic.classification", kind),
+		attribute.String("panic.value", fmt.Sprint(r)),
+		attribute.String("panic.type", fmt.Sprintf("%T", r)),
+		attribute.String("exception.stacktrace", stack),
+	))
+}
@@ -0,0 +1,195 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"reflect"
+
+	"buf.build/go/protovalidate"
+	"github.com/go-playground/validator/v10"
+	"github.com/itsLeonB/ungerr"
+	"github.com/rotisserie/eris"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// validationInterceptor validates incoming messages before they reach the handler
+type validationInterceptor struct {
+	protoValidator  protovalidate.Validator
+	structValidator *validator.Validate
+}
+
+// ValidationInterceptorOption configures the validation interceptor
+type ValidationInterceptorOption func(*validationInterceptor)
+
+// WithProtoValidator replaces the protovalidate validator, nil disables protovalidate rules
+func WithProtoValidator(protoValidator protovalidate.Validator) ValidationInterceptorOption {
+	return func(vi *validationInterceptor) {
+		vi.protoValidator = protoValidator
+	}
+}
+
+// WithStructValidator replaces the validator checking struct tags, nil disables struct tags
+func WithStructValidator(structValidator *validator.Validate) ValidationInterceptorOption {
+	return func(vi *validationInterceptor) {
+		vi.structValidator = structValidator
+	}
+}
+
+func NewValidationInterceptor(opts ...ValidationInterceptorOption) Interceptor {
+	return newValidationInterceptor(opts)
+}
+
+func NewStreamValidationInterceptor(opts ...ValidationInterceptorOption) StreamInterceptor {
+	return newValidationInterceptor(opts)
+}
+
+func newValidationInterceptor(opts []ValidationInterceptorOption) *validationInterceptor {
+	vi := &validationInterceptor{
+		protoValidator:  protovalidate.GlobalValidator,
+		structValidator: validator.New(validator.WithRequiredStructEnabled()),
+	}
+	for _, opt := range opts {
+		opt(vi)
+	}
+	return vi
+}
+
+// Handle rejects invalid requests before calling the handler
+func (vi *validationInterceptor) Handle(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	if err := vi.validate(req); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// HandleStream validates every message received from the client
+func (vi *validationInterceptor) HandleStream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &validationServerStream{ServerStream: ss, interceptor: vi})
+}
+
+// validate checks m with its own Validate method, then protovalidate rules, then struct tags.
+// Invalid messages are reported as an InvalidArgument status with BadRequest field violations.
+func (vi *validationInterceptor) validate(m any) error {
+	if err := validateMethod(m); err != nil {
+		return validationStatusError(err)
+	}
+
+	if msg, ok := m.(proto.Message); ok && vi.protoValidator != nil {
+		if err := vi.protoValidator.Validate(msg); err != nil {
+			var validationErr *protovalidate.ValidationError
+			if errors.As(err, &validationErr) {
+				return validationStatusError(validationErr)
+			}
+			// Compilation and runtime errors come from the rules, not the request
+			return eris.Wrap(err, "error evaluating protovalidate rules")
+		}
+	}
+
+	if vi.structValidator != nil && isStruct(m) {
+		err := vi.structValidator.Struct(m)
+		var validationErrs validator.ValidationErrors
+		if errors.As(err, &validationErrs) {
+			return validationStatusError(validationErrs)
+		}
+	}
+
+	return nil
+}
+
+// validateMethod calls the message's own validation, preferring ValidateAll
+// from protoc-gen-validate to report every violation at once
+func validateMethod(m any) error {
+	switch m := m.(type) {
+	case interface{ ValidateAll() error }:
+		return m.ValidateAll()
+	case interface{ Validate() error }:
+		return m.Validate()
+	default:
+		return nil
+	}
+}
+
+func isStruct(m any) bool {
+	t := reflect.TypeOf(m)
+	if t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t != nil && t.Kind() == reflect.Struct
+}
+
+// validationStatusError converts a validation failure into an InvalidArgument status error.
+// AppErrors and gRPC statuses returned by Validate methods are kept as is.
+func validationStatusError(err error) error {
+	if _, translated := innermostError(err, isTranslatedError); translated != nil {
+		if appErr, ok := translated.(ungerr.AppError); ok {
+			return appErrorStatus(appErr).Err()
+		}
+		return translated.(grpcStatusError).GRPCStatus().Err()
+	}
+
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		return appErrorStatus(newFieldValidationError(validationErrs)).Err()
+	}
+
+	violations := fieldViolations(err)
+	descriptions := make([]string, 0, len(violations))
+	for _, violation := range violations {
+		descriptions = append(descriptions, violation.GetDescription())
+	}
+	return appErrorStatus(fieldViolationError{ungerr.ValidationError(descriptions), violations, nil}).Err()
+}
+
+// fieldViolations extracts field violations from protovalidate and protoc-gen-validate errors,
+// falling back to a single violation describing err
+func fieldViolations(err error) []*errdetails.BadRequest_FieldViolation {
+	var validationErr *protovalidate.ValidationError
+	if errors.As(err, &validationErr) {
+		violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(validationErr.Violations))
+		for _, v := range validationErr.Violations {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{
+				Field:       protovalidate.FieldPathString(v.Proto.GetField()),
+				Description: v.Proto.GetMessage(),
+				Reason:      v.Proto.GetRuleId(),
+			})
+		}
+		return violations
+	}
+
+	// protoc-gen-validate reports all violations through a MultiError
+	if multi, ok := err.(interface{ AllErrors() []error }); ok {
+		var violations []*errdetails.BadRequest_FieldViolation
+		for _, e := range multi.AllErrors() {
+			violations = append(violations, fieldViolations(e)...)
+		}
+		return violations
+	}
+
+	// protoc-gen-validate field errors
+	if fieldErr, ok := err.(interface {
+		Field() string
+		Reason() string
+	}); ok {
+		return []*errdetails.BadRequest_FieldViolation{{
+			Field:       fieldErr.Field(),
+			Description: fieldErr.Reason(),
+		}}
+	}
+
+	return []*errdetails.BadRequest_FieldViolation{{Description: err.Error()}}
+}
+
+// validationServerStream validates every message received on the stream
+type validationServerStream struct {
+	grpc.ServerStream
+	interceptor *validationInterceptor
+}
+
+func (s *validationServerStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return s.interceptor.validate(m)
+}
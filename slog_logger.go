@@ -0,0 +1,63 @@
+package gerpc
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/itsLeonB/gerpc/internal"
+)
+
+// LeveledLogger can be implemented alongside ezutil.Logger to have gerpc emit
+// records with typed key/value attributes (method, code, duration_ms, peer,
+// request_id, ...) instead of printf-style lines. Errors and panics are then
+// logged as a single record with their classification and stack.
+// Its signature matches (*slog.Logger).Log.
+type LeveledLogger = internal.LeveledLogger
+
+// SlogLogger adapts a *slog.Logger to ezutil.Logger so it can be passed to
+// GrpcServer.WithLogger, NewLoggingInterceptor and NewErrorInterceptor.
+// Printf-style calls are logged as formatted messages, while the interceptors
+// detect the structured methods and log typed attributes.
+type SlogLogger struct {
+	logger *slog.Logger
+}
+
+// NewSlogLogger wraps logger, falling back to slog.Default when it is nil.
+func NewSlogLogger(logger *slog.Logger) *SlogLogger {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &SlogLogger{logger}
+}
+
+func (l *SlogLogger) Debug(args ...any) { l.logger.Debug(fmt.Sprint(args...)) }
+func (l *SlogLogger) Info(args ...any)  { l.logger.Info(fmt.Sprint(args...)) }
+func (l *SlogLogger) Warn(args ...any)  { l.logger.Warn(fmt.Sprint(args...)) }
+func (l *SlogLogger) Error(args ...any) { l.logger.Error(fmt.Sprint(args...)) }
+
+func (l *SlogLogger) Fatal(args ...any) {
+	l.logger.Error(fmt.Sprint(args...))
+	os.Exit(1)
+}
+
+func (l *SlogLogger) Debugf(format string, args ...any) { l.logger.Debug(fmt.Sprintf(format, args...)) }
+func (l *SlogLogger) Infof(format string, args ...any)  { l.logger.Info(fmt.Sprintf(format, args...)) }
+func (l *SlogLogger) Warnf(format string, args ...any)  { l.logger.Warn(fmt.Sprintf(format, args...)) }
+func (l *SlogLogger) Errorf(format string, args ...any) { l.logger.Error(fmt.Sprintf(format, args...)) }
+
+func (l *SlogLogger) Fatalf(format string, args ...any) {
+	l.logger.Error(fmt.Sprintf(format, args...))
+	os.Exit(1)
+}
+
+// Log emits a structured record, satisfying LeveledLogger
+func (l *SlogLogger) Log(ctx context.Context, level slog.Level, msg string, args ...any) {
+	l.logger.Log(ctx, level, msg, args...)
+}
+
+// Slog returns the wrapped *slog.Logger
+func (l *SlogLogger) Slog() *slog.Logger {
+	return l.logger
+}
@@ -0,0 +1,18 @@
+package gerpc_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/itsLeonB/gerpc"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestFromStatus(t *testing.T) {
+	appErr := gerpc.FromStatus(status.Error(codes.AlreadyExists, "duplicate"))
+
+	assert.Equal(t, http.StatusConflict, appErr.HttpStatus())
+	assert.Nil(t, gerpc.FromStatus(nil))
+}
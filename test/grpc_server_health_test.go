@@ -0,0 +1,79 @@
+package gerpc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/itsLeonB/gerpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestGrpcServer_Health_Lifecycle(t *testing.T) {
+	address := freeAddress(t)
+	server := gerpc.NewGrpcServer().
+		WithLogger(newRunLogger()).
+		WithAddress(address).
+		WithRegisterSrvFunc(func(*grpc.Server) error { return nil })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- server.RunContext(ctx) }()
+
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+	client := grpc_health_v1.NewHealthClient(conn)
+
+	watchCtx, stopWatch := context.WithCancel(context.Background())
+	defer stopWatch()
+	stream, err := client.Watch(watchCtx, &grpc_health_v1.HealthCheckRequest{}, grpc.WaitForReady(true))
+	assert.NoError(t, err)
+
+	resp, err := stream.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, resp.GetStatus())
+
+	server.SetServingStatus("test.Dependency", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	checkResp, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: "test.Dependency"})
+	assert.NoError(t, err)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, checkResp.GetStatus())
+
+	cancel()
+
+	resp, err = stream.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, resp.GetStatus())
+	stopWatch()
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("RunContext did not return after cancellation")
+	}
+}
+
+func TestGrpcServer_Health_SkipsWhenAlreadyRegistered(t *testing.T) {
+	logger := newRunLogger()
+	logger.On("Warn", mock.Anything).Return()
+
+	server := gerpc.NewGrpcServer().
+		WithLogger(logger).
+		WithAddress("127.0.0.1:0").
+		WithRegisterSrvFunc(func(s *grpc.Server) error {
+			grpc_health_v1.RegisterHealthServer(s, health.NewServer())
+			return nil
+		})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.NoError(t, server.RunContext(ctx))
+	logger.AssertCalled(t, "Warn", "health service already registered, skipping built-in health service")
+}
@@ -0,0 +1,145 @@
+package gerpc_test
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/itsLeonB/gerpc"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func scrapeMetrics(address string) (string, error) {
+	resp, err := http.Get("http://" + address + "/metrics")
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	return string(body), err
+}
+
+func TestGrpcServer_WithMetricsAddress(t *testing.T) {
+	server := gerpc.NewGrpcServer()
+
+	assert.Equal(t, server, server.WithMetricsAddress(":9090"))
+	assert.Equal(t, server, server.WithMetricsGatherer(prometheus.NewRegistry()))
+	assert.Equal(t, server, server.WithMetricsOptions(gerpc.WithLatencyBuckets(1)))
+}
+
+func TestGrpcServer_RunContext_Metrics(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	address := freeAddress(t)
+	metricsAddress := freeAddress(t)
+
+	server := gerpc.NewGrpcServer().
+		WithLogger(newRunLogger()).
+		WithAddress(address).
+		WithMetricsAddress(metricsAddress).
+		WithMetricsGatherer(registry).
+		WithMetricsOptions(gerpc.WithLatencyBuckets(0.1, 1)).
+		WithRegisterSrvFunc(func(*grpc.Server) error { return nil })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- server.RunContext(ctx) }()
+
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+
+	checkCtx, checkCancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer checkCancel()
+	_, err = grpc_health_v1.NewHealthClient(conn).Check(checkCtx, &grpc_health_v1.HealthCheckRequest{}, grpc.WaitForReady(true))
+	require.NoError(t, err)
+
+	body, err := scrapeMetrics(metricsAddress)
+	require.NoError(t, err)
+	assert.Contains(t, body, `grpc_server_handled_total{code="OK",method="/grpc.health.v1.Health/Check"} 1`)
+	assert.Contains(t, body, `grpc_server_handling_seconds_count{method="/grpc.health.v1.Health/Check"} 1`)
+	assert.Contains(t, body, `grpc_server_handling_seconds_bucket{method="/grpc.health.v1.Health/Check",le="0.1"}`)
+
+	cancel()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("RunContext did not return after cancellation")
+	}
+
+	_, err = scrapeMetrics(metricsAddress)
+	assert.Error(t, err, "metrics server should stop with the gRPC server")
+}
+
+func TestGrpcServer_RunContext_MetricsServedWhileDraining(t *testing.T) {
+	logger := newRunLogger()
+	logger.On("Warnf", mock.Anything, mock.Anything).Return()
+	logger.On("Warnf", mock.Anything, mock.Anything, mock.Anything).Return()
+
+	address := freeAddress(t)
+	metricsAddress := freeAddress(t)
+	server := gerpc.NewGrpcServer().
+		WithLogger(logger).
+		WithAddress(address).
+		WithMetricsAddress(metricsAddress).
+		WithMetricsGatherer(prometheus.NewRegistry()).
+		WithShutdownTimeout(500 * time.Millisecond).
+		WithRegisterSrvFunc(func(*grpc.Server) error { return nil })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- server.RunContext(ctx) }()
+
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+
+	// Watch is a long-lived stream keeping the server draining until the timeout
+	stream, err := grpc_health_v1.NewHealthClient(conn).Watch(context.Background(), &grpc_health_v1.HealthCheckRequest{}, grpc.WaitForReady(true))
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	require.NoError(t, err)
+
+	cancel()
+
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, resp.GetStatus())
+	_, err = scrapeMetrics(metricsAddress)
+	assert.NoError(t, err, "metrics should be served until the drain completes")
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("RunContext did not return after shutdown timeout")
+	}
+
+	_, err = scrapeMetrics(metricsAddress)
+	assert.Error(t, err, "metrics server should stop with the gRPC server")
+}
+
+func TestGrpcServer_RunContext_MetricsListenError(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() { _ = listener.Close() }()
+
+	server := gerpc.NewGrpcServer().
+		WithLogger(newRunLogger()).
+		WithAddress(freeAddress(t)).
+		WithMetricsAddress(listener.Addr().String()).
+		WithRegisterSrvFunc(func(*grpc.Server) error { return nil })
+
+	err = server.RunContext(context.Background())
+	assert.ErrorContains(t, err, "metrics")
+}
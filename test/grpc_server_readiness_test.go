@@ -0,0 +1,256 @@
+package gerpc_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/itsLeonB/gerpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestGrpcServer_WithReadinessCheck(t *testing.T) {
+	server := gerpc.NewGrpcServer()
+
+	result := server.WithReadinessCheck("db", func(context.Context) error { return nil }, time.Second)
+	assert.Equal(t, server, result)
+	assert.Equal(t, []gerpc.ReadinessCheckResult{{Name: "db"}}, server.ReadinessResults())
+	// Checks that have not run yet count as not ready
+	assert.False(t, server.Ready())
+	assert.True(t, gerpc.NewGrpcServer().Ready())
+}
+
+func TestGrpcServer_WithReadinessCheck_InvalidInterval(t *testing.T) {
+	assert.Panics(t, func() {
+		gerpc.NewGrpcServer().WithReadinessCheck("db", func(context.Context) error { return nil }, 0)
+	})
+}
+
+func TestGrpcServer_ReadinessCheck_DrivesHealth(t *testing.T) {
+	logger := newRunLogger()
+	logger.On("Warnf", mock.Anything, mock.Anything, mock.Anything).Return()
+
+	var failing atomic.Bool
+	failing.Store(true)
+	checkErr := errors.New("db unreachable")
+
+	address := freeAddress(t)
+	server := gerpc.NewGrpcServer().
+		WithLogger(logger).
+		WithAddress(address).
+		WithRegisterSrvFunc(func(*grpc.Server) error { return nil }).
+		WithReadinessCheck("cache", func(context.Context) error { return nil }, 10*time.Millisecond).
+		WithReadinessCheck("db", func(context.Context) error {
+			if failing.Load() {
+				return checkErr
+			}
+			return nil
+		}, 10*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- server.RunContext(ctx) }()
+
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+	client := grpc_health_v1.NewHealthClient(conn)
+
+	checkStatus := func(service string) grpc_health_v1.HealthCheckResponse_ServingStatus {
+		resp, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: service}, grpc.WaitForReady(true))
+		if err != nil {
+			return grpc_health_v1.HealthCheckResponse_UNKNOWN
+		}
+		return resp.GetStatus()
+	}
+
+	assert.Eventually(t, func() bool {
+		return checkStatus("db") == grpc_health_v1.HealthCheckResponse_NOT_SERVING &&
+			checkStatus("") == grpc_health_v1.HealthCheckResponse_NOT_SERVING
+	}, 2*time.Second, 10*time.Millisecond)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, checkStatus("cache"))
+	assert.False(t, server.Ready())
+
+	results := server.ReadinessResults()
+	assert.Len(t, results, 2)
+	assert.Equal(t, "cache", results[0].Name)
+	assert.NoError(t, results[0].Err)
+	assert.Equal(t, "db", results[1].Name)
+	assert.ErrorIs(t, results[1].Err, checkErr)
+	logger.AssertCalled(t, "Warnf", "readiness check %s failing: %v", "db", checkErr)
+
+	failing.Store(false)
+
+	assert.Eventually(t, func() bool {
+		return checkStatus("db") == grpc_health_v1.HealthCheckResponse_SERVING &&
+			checkStatus("") == grpc_health_v1.HealthCheckResponse_SERVING
+	}, 2*time.Second, 10*time.Millisecond)
+	assert.True(t, server.Ready())
+	logger.AssertCalled(t, "Infof", "readiness check %s recovered", "db")
+
+	cancel()
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("RunContext did not return after cancellation")
+	}
+}
+
+func TestGrpcServer_ReadinessCheck_NotServingBeforeFirstPass(t *testing.T) {
+	logger := newRunLogger()
+
+	release := make(chan struct{})
+	address := freeAddress(t)
+	server := gerpc.NewGrpcServer().
+		WithLogger(logger).
+		WithAddress(address).
+		WithRegisterSrvFunc(func(*grpc.Server) error { return nil }).
+		WithReadinessCheck("db", func(ctx context.Context) error {
+			select {
+			case <-release:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}, time.Minute)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- server.RunContext(ctx) }()
+
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+	client := grpc_health_v1.NewHealthClient(conn)
+
+	checkStatus := func(service string) grpc_health_v1.HealthCheckResponse_ServingStatus {
+		resp, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: service}, grpc.WaitForReady(true))
+		if err != nil {
+			return grpc_health_v1.HealthCheckResponse_UNKNOWN
+		}
+		return resp.GetStatus()
+	}
+
+	// The first probe is still running
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, checkStatus(""))
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, checkStatus("db"))
+	assert.False(t, server.Ready())
+
+	close(release)
+
+	assert.Eventually(t, func() bool {
+		return checkStatus("") == grpc_health_v1.HealthCheckResponse_SERVING &&
+			checkStatus("db") == grpc_health_v1.HealthCheckResponse_SERVING
+	}, 2*time.Second, 10*time.Millisecond)
+	assert.True(t, server.Ready())
+
+	cancel()
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("RunContext did not return after cancellation")
+	}
+}
+
+func TestGrpcServer_ReadinessCheck_DoesNotBlockShutdown(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	blocked := make(chan struct{})
+
+	var calls atomic.Int32
+	server := gerpc.NewGrpcServer().
+		WithLogger(newRunLogger()).
+		WithAddress(freeAddress(t)).
+		WithRegisterSrvFunc(func(*grpc.Server) error { return nil }).
+		WithReadinessCheck("db", func(context.Context) error {
+			if calls.Add(1) == 2 {
+				// Ignores its context
+				close(blocked)
+				<-release
+			}
+			return nil
+		}, 10*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- server.RunContext(ctx) }()
+
+	select {
+	case <-blocked:
+	case <-time.After(2 * time.Second):
+		t.Fatal("readiness check was not probed again")
+	}
+	cancel()
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("RunContext waited for a readiness check ignoring its context")
+	}
+}
+
+func TestGrpcServer_ReadinessCheck_NamedAfterService(t *testing.T) {
+	release := make(chan struct{})
+	address := freeAddress(t)
+	server := gerpc.NewGrpcServer().
+		WithLogger(newRunLogger()).
+		WithAddress(address).
+		WithRegisterSrvFunc(func(s *grpc.Server) error {
+			s.RegisterService(&testServiceDesc, struct{}{})
+			return nil
+		}).
+		WithReadinessCheck(testServiceDesc.ServiceName, func(ctx context.Context) error {
+			select {
+			case <-release:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}, time.Minute)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- server.RunContext(ctx) }()
+
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+	client := grpc_health_v1.NewHealthClient(conn)
+
+	checkStatus := func() grpc_health_v1.HealthCheckResponse_ServingStatus {
+		resp, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: testServiceDesc.ServiceName}, grpc.WaitForReady(true))
+		if err != nil {
+			return grpc_health_v1.HealthCheckResponse_UNKNOWN
+		}
+		return resp.GetStatus()
+	}
+
+	// The service is not SERVING before its check has passed
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, checkStatus())
+
+	close(release)
+
+	assert.Eventually(t, func() bool {
+		return checkStatus() == grpc_health_v1.HealthCheckResponse_SERVING
+	}, 2*time.Second, 10*time.Millisecond)
+
+	cancel()
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("RunContext did not return after cancellation")
+	}
+}
@@ -1,11 +1,18 @@
 package gerpc_test
 
 import (
+	"context"
+	"errors"
+	"net"
 	"testing"
+	"time"
 
 	"github.com/itsLeonB/gerpc"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
 )
 
 func TestNewGrpcServer(t *testing.T) {
@@ -48,3 +55,132 @@ func TestGrpcServer_WithShutdownFunc(t *testing.T) {
 	result := server.WithShutdownFunc(func() error { return nil })
 	assert.Equal(t, server, result)
 }
+
+func newRunLogger() *MockLogger {
+	logger := &MockLogger{}
+	logger.On("Info", mock.Anything).Return().Maybe()
+	logger.On("Infof", mock.Anything, mock.Anything).Return().Maybe()
+	logger.On("Errorf", mock.Anything, mock.Anything).Return().Maybe()
+	return logger
+}
+
+func freeAddress(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	address := listener.Addr().String()
+	assert.NoError(t, listener.Close())
+	return address
+}
+
+func TestGrpcServer_RunContext_StopsOnCancel(t *testing.T) {
+	shutdownCalled := false
+	server := gerpc.NewGrpcServer().
+		WithLogger(newRunLogger()).
+		WithAddress("127.0.0.1:0").
+		WithRegisterSrvFunc(func(*grpc.Server) error { return nil }).
+		WithShutdownFunc(func() error {
+			shutdownCalled = true
+			return nil
+		})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- server.RunContext(ctx) }()
+
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+		assert.True(t, shutdownCalled)
+	case <-time.After(5 * time.Second):
+		t.Fatal("RunContext did not return after cancellation")
+	}
+}
+
+func TestGrpcServer_RunContext_ListenError(t *testing.T) {
+	server := gerpc.NewGrpcServer().
+		WithLogger(newRunLogger()).
+		WithAddress("invalid-address").
+		WithRegisterSrvFunc(func(*grpc.Server) error { return nil })
+
+	err := server.RunContext(context.Background())
+	assert.Error(t, err)
+}
+
+func TestGrpcServer_RunContext_RegisterError(t *testing.T) {
+	registerErr := errors.New("register failed")
+	server := gerpc.NewGrpcServer().
+		WithLogger(newRunLogger()).
+		WithAddress("127.0.0.1:0").
+		WithRegisterSrvFunc(func(*grpc.Server) error { return registerErr })
+
+	err := server.RunContext(context.Background())
+	assert.ErrorIs(t, err, registerErr)
+}
+
+func TestGrpcServer_RunContext_ShutdownFuncError(t *testing.T) {
+	shutdownErr := errors.New("cleanup failed")
+	server := gerpc.NewGrpcServer().
+		WithLogger(newRunLogger()).
+		WithAddress("127.0.0.1:0").
+		WithRegisterSrvFunc(func(*grpc.Server) error { return nil }).
+		WithShutdownFunc(func() error { return shutdownErr })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := server.RunContext(ctx)
+	assert.ErrorIs(t, err, shutdownErr)
+}
+
+func TestGrpcServer_WithShutdownTimeout(t *testing.T) {
+	server := gerpc.NewGrpcServer()
+
+	result := server.WithShutdownTimeout(time.Second)
+	assert.Equal(t, server, result)
+}
+
+func TestGrpcServer_RunContext_ForcesStopAfterShutdownTimeout(t *testing.T) {
+	logger := newRunLogger()
+	logger.On("Warnf", mock.Anything, mock.Anything).Return()
+	logger.On("Warnf", mock.Anything, mock.Anything, mock.Anything).Return()
+
+	address := freeAddress(t)
+	shutdownCalled := false
+	server := gerpc.NewGrpcServer().
+		WithLogger(logger).
+		WithAddress(address).
+		WithShutdownTimeout(100 * time.Millisecond).
+		WithRegisterSrvFunc(func(*grpc.Server) error { return nil }).
+		WithShutdownFunc(func() error {
+			shutdownCalled = true
+			return nil
+		})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- server.RunContext(ctx) }()
+
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+
+	// Watch is a long-lived stream that never ends on its own
+	stream, err := grpc_health_v1.NewHealthClient(conn).Watch(context.Background(), &grpc_health_v1.HealthCheckRequest{}, grpc.WaitForReady(true))
+	assert.NoError(t, err)
+	_, err = stream.Recv()
+	assert.NoError(t, err)
+
+	cancel()
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+		assert.True(t, shutdownCalled)
+		logger.AssertCalled(t, "Warnf", "RPC still in flight: method=%s count=%d", "/grpc.health.v1.Health/Watch", 1)
+	case <-time.After(5 * time.Second):
+		t.Fatal("RunContext did not return after shutdown timeout")
+	}
+}
@@ -0,0 +1,276 @@
+package gerpc_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/itsLeonB/gerpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+type testCert struct {
+	cert     *x509.Certificate
+	key      *ecdsa.PrivateKey
+	certFile string
+	keyFile  string
+}
+
+// newTestCert writes a PEM certificate and key to dir, self-signed when parent is nil
+func newTestCert(t *testing.T, dir, name string, parent *testCert, isCA bool) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+	}
+	if isCA {
+		template.KeyUsage |= x509.KeyUsageCertSign
+	}
+
+	signer, signerKey := template, key
+	if parent != nil {
+		signer, signerKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	tc := &testCert{
+		cert:     cert,
+		key:      key,
+		certFile: filepath.Join(dir, name+".crt"),
+		keyFile:  filepath.Join(dir, name+".key"),
+	}
+	require.NoError(t, os.WriteFile(tc.certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(tc.keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+
+	return tc
+}
+
+func (tc *testCert) pool() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(tc.cert)
+	return pool
+}
+
+func (tc *testCert) keyPair(t *testing.T) tls.Certificate {
+	pair, err := tls.LoadX509KeyPair(tc.certFile, tc.keyFile)
+	require.NoError(t, err)
+	return pair
+}
+
+func startServer(t *testing.T, server *gerpc.GrpcServer) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- server.RunContext(ctx) }()
+
+	t.Cleanup(func() {
+		cancel()
+		select {
+		case err := <-done:
+			assert.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			t.Error("RunContext did not return after cancellation")
+		}
+	})
+}
+
+func healthCheck(address string, tlsConfig *tls.Config) error {
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	_, err = grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	return err
+}
+
+func TestGrpcServer_WithTLS(t *testing.T) {
+	server := gerpc.NewGrpcServer()
+
+	assert.Equal(t, server, server.WithTLS("server.crt", "server.key"))
+	assert.Equal(t, server, server.WithMutualTLS("ca.crt"))
+}
+
+func TestGrpcServer_RunContext_TLS(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCert(t, dir, "ca", nil, true)
+	serverCert := newTestCert(t, dir, "server", ca, false)
+
+	address := freeAddress(t)
+	startServer(t, gerpc.NewGrpcServer().
+		WithLogger(newRunLogger()).
+		WithAddress(address).
+		WithRegisterSrvFunc(func(*grpc.Server) error { return nil }).
+		WithTLS(serverCert.certFile, serverCert.keyFile))
+
+	assert.Eventually(t, func() bool {
+		return healthCheck(address, &tls.Config{RootCAs: ca.pool()}) == nil
+	}, 2*time.Second, 20*time.Millisecond)
+
+	err := healthCheck(address, &tls.Config{RootCAs: ca.pool(), MaxVersion: tls.VersionTLS11})
+	assert.Error(t, err)
+}
+
+func TestGrpcServer_RunContext_MutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCert(t, dir, "ca", nil, true)
+	serverCert := newTestCert(t, dir, "server", ca, false)
+	clientCert := newTestCert(t, dir, "client", ca, false)
+
+	address := freeAddress(t)
+	startServer(t, gerpc.NewGrpcServer().
+		WithLogger(newRunLogger()).
+		WithAddress(address).
+		WithRegisterSrvFunc(func(*grpc.Server) error { return nil }).
+		WithTLS(serverCert.certFile, serverCert.keyFile).
+		WithMutualTLS(ca.certFile))
+
+	assert.Eventually(t, func() bool {
+		return healthCheck(address, &tls.Config{
+			RootCAs:      ca.pool(),
+			Certificates: []tls.Certificate{clientCert.keyPair(t)},
+		}) == nil
+	}, 2*time.Second, 20*time.Millisecond)
+
+	err := healthCheck(address, &tls.Config{RootCAs: ca.pool()})
+	assert.Error(t, err)
+}
+
+func TestGrpcServer_RunContext_InvalidTLSMaterial(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCert(t, dir, "ca", nil, true)
+	serverCert := newTestCert(t, dir, "server", ca, false)
+	garbage := filepath.Join(dir, "garbage.pem")
+	require.NoError(t, os.WriteFile(garbage, []byte("not a pem"), 0o600))
+
+	tests := []struct {
+		name   string
+		server *gerpc.GrpcServer
+	}{
+		{"missing key pair", gerpc.NewGrpcServer().WithTLS(filepath.Join(dir, "missing.crt"), filepath.Join(dir, "missing.key"))},
+		{"invalid key pair", gerpc.NewGrpcServer().WithTLS(garbage, serverCert.keyFile)},
+		{"invalid CA bundle", gerpc.NewGrpcServer().WithTLS(serverCert.certFile, serverCert.keyFile).WithMutualTLS(garbage)},
+		{"mutual TLS without key pair", gerpc.NewGrpcServer().WithMutualTLS(ca.certFile)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.server.
+				WithLogger(newRunLogger()).
+				WithAddress("127.0.0.1:0").
+				WithRegisterSrvFunc(func(*grpc.Server) error { return nil }).
+				RunContext(context.Background())
+			assert.Error(t, err)
+		})
+	}
+}
+
+func servedSerial(t *testing.T, address string) *big.Int {
+	conn, err := tls.Dial("tcp", address, &tls.Config{
+		InsecureSkipVerify: true, // #nosec G402 -- only inspecting the served certificate
+		NextProtos:         []string{"h2"},
+	})
+	require.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+
+	return conn.ConnectionState().PeerCertificates[0].SerialNumber
+}
+
+// replaceCert overwrites the PEM files of dst and bumps their modification time
+func replaceCert(t *testing.T, dst *testCert, certPEM, keyPEM []byte) {
+	require.NoError(t, os.WriteFile(dst.certFile, certPEM, 0o600))
+	require.NoError(t, os.WriteFile(dst.keyFile, keyPEM, 0o600))
+
+	later := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(dst.certFile, later, later))
+	require.NoError(t, os.Chtimes(dst.keyFile, later, later))
+}
+
+func TestGrpcServer_RunContext_TLSReload(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCert(t, dir, "ca", nil, true)
+	serverCert := newTestCert(t, dir, "server", ca, false)
+	rotatedCert := newTestCert(t, dir, "rotated", ca, false)
+
+	reloadFailed := make(chan struct{}, 1)
+	logger := &MockLogger{}
+	logger.On("Errorf", "error reloading TLS certificates, keeping previous certificates: %v", mock.Anything).
+		Run(func(mock.Arguments) {
+			select {
+			case reloadFailed <- struct{}{}:
+			default:
+			}
+		}).
+		Return()
+	logger.On("Info", mock.Anything).Return().Maybe()
+	logger.On("Infof", mock.Anything, mock.Anything).Return().Maybe()
+
+	address := freeAddress(t)
+	startServer(t, gerpc.NewGrpcServer().
+		WithLogger(logger).
+		WithAddress(address).
+		WithRegisterSrvFunc(func(*grpc.Server) error { return nil }).
+		WithTLS(serverCert.certFile, serverCert.keyFile).
+		WithTLSReloadInterval(10*time.Millisecond))
+
+	assert.Eventually(t, func() bool {
+		return healthCheck(address, &tls.Config{RootCAs: ca.pool()}) == nil
+	}, 2*time.Second, 20*time.Millisecond)
+	assert.Equal(t, serverCert.cert.SerialNumber, servedSerial(t, address))
+
+	certPEM, err := os.ReadFile(rotatedCert.certFile)
+	require.NoError(t, err)
+	keyPEM, err := os.ReadFile(rotatedCert.keyFile)
+	require.NoError(t, err)
+	replaceCert(t, serverCert, certPEM, keyPEM)
+
+	assert.Eventually(t, func() bool {
+		return servedSerial(t, address).Cmp(rotatedCert.cert.SerialNumber) == 0
+	}, 2*time.Second, 20*time.Millisecond)
+	logger.AssertCalled(t, "Infof", "TLS certificates reloaded from %s", serverCert.certFile)
+
+	replaceCert(t, serverCert, []byte("not a pem"), keyPEM)
+
+	select {
+	case <-reloadFailed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("invalid certificate was not reported")
+	}
+	assert.Equal(t, rotatedCert.cert.SerialNumber, servedSerial(t, address))
+	assert.NoError(t, healthCheck(address, &tls.Config{RootCAs: ca.pool()}))
+}
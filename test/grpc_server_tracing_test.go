@@ -0,0 +1,128 @@
+package gerpc_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/itsLeonB/gerpc"
+	"github.com/rotisserie/eris"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// testServiceDesc describes a service whose methods panic or fail, without generated code
+var testServiceDesc = grpc.ServiceDesc{
+	ServiceName: "test.Test",
+	HandlerType: (*any)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "Panic", Handler: testMethodHandler("/test.Test/Panic", func() error {
+			panic("boom")
+		})},
+		{MethodName: "Fail", Handler: testMethodHandler("/test.Test/Fail", func() error {
+			return eris.Wrap(errors.New("database unavailable"), "error loading user")
+		})},
+	},
+}
+
+func testMethodHandler(fullMethod string, fn func() error) grpc.MethodHandler {
+	return func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+		req := &emptypb.Empty{}
+		if err := dec(req); err != nil {
+			return nil, err
+		}
+		handler := func(ctx context.Context, req any) (any, error) {
+			return &emptypb.Empty{}, fn()
+		}
+		if interceptor == nil {
+			return handler(ctx, req)
+		}
+		return interceptor(ctx, req, &grpc.UnaryServerInfo{Server: srv, FullMethod: fullMethod}, handler)
+	}
+}
+
+func findSpan(t *testing.T, exporter *tracetest.InMemoryExporter, name string) tracetest.SpanStub {
+	for _, span := range exporter.GetSpans() {
+		if span.Name == name {
+			return span
+		}
+	}
+	t.Fatalf("span %s not recorded", name)
+	return tracetest.SpanStub{}
+}
+
+func hasEvent(span tracetest.SpanStub, name string) bool {
+	for _, event := range span.Events {
+		if event.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func TestGrpcServer_WithTracing(t *testing.T) {
+	server := gerpc.NewGrpcServer()
+
+	assert.Equal(t, server, server.WithTracing(nil))
+}
+
+func TestGrpcServer_RunContext_Tracing(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+	logger := newRunLogger()
+	logger.On("Error", mock.Anything, mock.Anything).Return().Maybe()
+
+	address := freeAddress(t)
+	server := gerpc.NewGrpcServer().
+		WithLogger(logger).
+		WithAddress(address).
+		WithTracing(provider).
+		WithOpts(grpc.ChainUnaryInterceptor(
+			gerpc.NewRequestIDInterceptor(),
+			gerpc.NewErrorInterceptor(logger),
+		)).
+		WithRegisterSrvFunc(func(s *grpc.Server) error {
+			s.RegisterService(&testServiceDesc, struct{}{})
+			return nil
+		})
+	startServer(t, server)
+
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, gerpc.RequestIDKey, "req-1")
+
+	err = conn.Invoke(ctx, "/test.Test/Panic", &emptypb.Empty{}, &emptypb.Empty{}, grpc.WaitForReady(true))
+	assert.Equal(t, codes.Internal, status.Code(err))
+	err = conn.Invoke(ctx, "/test.Test/Fail", &emptypb.Empty{}, &emptypb.Empty{})
+	assert.Equal(t, codes.Internal, status.Code(err))
+
+	// Server spans end after the response is written, wait for both
+	assert.Eventually(t, func() bool { return len(exporter.GetSpans()) == 2 }, 2*time.Second, 10*time.Millisecond)
+
+	panicSpan := findSpan(t, exporter, "test.Test/Panic")
+	assert.Equal(t, otelcodes.Error, panicSpan.Status.Code)
+	assert.Contains(t, panicSpan.Attributes, attribute.String(gerpc.RequestIDAttribute, "req-1"))
+	assert.True(t, hasEvent(panicSpan, "panic"))
+
+	failSpan := findSpan(t, exporter, "test.Test/Fail")
+	assert.Equal(t, otelcodes.Error, failSpan.Status.Code)
+	assert.True(t, hasEvent(failSpan, "exception"))
+}
@@ -0,0 +1,208 @@
+package gerpc_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/itsLeonB/gerpc"
+	"github.com/rotisserie/eris"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// chainUnary runs handler through interceptors, outermost first, like grpc.ChainUnaryInterceptor
+func chainUnary(ctx context.Context, req any, interceptors []grpc.UnaryServerInterceptor, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], handler
+		handler = func(ctx context.Context, req any) (any, error) {
+			return interceptor(ctx, req, info, next)
+		}
+	}
+	return handler(ctx, req)
+}
+
+func recordingInterceptors(calls *[]string, name string) (grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor) {
+	unary := func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		*calls = append(*calls, name)
+		return handler(ctx, req)
+	}
+	stream := func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		*calls = append(*calls, name)
+		return handler(srv, ss)
+	}
+	return unary, stream
+}
+
+func TestInterceptorChain_InsertionOrder(t *testing.T) {
+	var calls []string
+	chain := gerpc.NewInterceptorChain().
+		Without(gerpc.StageRequestID, gerpc.StageRecovery, gerpc.StageLogging, gerpc.StageErrors, gerpc.StageValidation)
+
+	for _, stage := range []gerpc.InterceptorStage{gerpc.StageValidation, gerpc.StageErrors, gerpc.StageLogging, gerpc.StageRecovery, gerpc.StageRequestID} {
+		unary, stream := recordingInterceptors(&calls, "after "+string(stage))
+		chain.InsertAfter(stage, unary, stream)
+		unary, stream = recordingInterceptors(&calls, "before "+string(stage))
+		chain.InsertBefore(stage, unary, stream)
+	}
+
+	expected := []string{
+		"before request_id", "after request_id",
+		"before recovery", "after recovery",
+		"before logging", "after logging",
+		"before errors", "after errors",
+		"before validation", "after validation",
+	}
+
+	unary := chain.UnaryInterceptors(&MockLogger{})
+	require.Len(t, unary, len(expected))
+	_, err := chainUnary(context.Background(), nil, unary, &grpc.UnaryServerInfo{}, func(ctx context.Context, req any) (any, error) {
+		return nil, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, expected, calls)
+
+	calls = nil
+	stream := chain.StreamInterceptors(&MockLogger{})
+	require.Len(t, stream, len(expected))
+	for _, interceptor := range stream {
+		assert.NoError(t, interceptor(nil, nil, &grpc.StreamServerInfo{}, func(srv any, ss grpc.ServerStream) error { return nil }))
+	}
+	assert.Equal(t, expected, calls)
+}
+
+func TestInterceptorChain_UnknownStage(t *testing.T) {
+	assert.PanicsWithValue(t, `unknown interceptor stage "auth"`, func() {
+		gerpc.NewInterceptorChain().InsertBefore("auth", nil, nil)
+	})
+}
+
+func TestInterceptorChain_LogsMappedStatus(t *testing.T) {
+	logger, buf := newJSONLogger()
+	chain := gerpc.NewInterceptorChain()
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(gerpc.RequestIDKey, "req-1"))
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}
+	_, err := chainUnary(ctx, nil, chain.UnaryInterceptors(logger), info, func(ctx context.Context, req any) (any, error) {
+		return nil, eris.Wrap(errors.New("database unavailable"), "error loading user")
+	})
+	assert.Equal(t, codes.Internal, status.Code(err))
+
+	records := decodeRecords(t, buf)
+	require.Len(t, records, 2)
+	// The error interceptor logs the masked error, then the logging interceptor the mapped status
+	assert.Equal(t, "unhandled error", records[0]["msg"])
+	assert.Equal(t, "req-1", records[0]["request_id"])
+	assert.Equal(t, "gRPC call failed", records[1]["msg"])
+	assert.Equal(t, "Internal", records[1]["code"])
+	assert.Equal(t, "req-1", records[1]["request_id"])
+}
+
+func TestInterceptorChain_WithOptions(t *testing.T) {
+	logger, buf := newJSONLogger()
+	chain := gerpc.NewInterceptorChain().
+		WithRequestIDOptions(gerpc.WithRequestIDGenerator(func() string { return "generated" })).
+		WithLoggingOptions(gerpc.WithSkipMethods("/test.Service/Skipped")).
+		WithErrorOptions(gerpc.WithDevelopmentMode())
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}
+	_, err := chainUnary(context.Background(), nil, chain.UnaryInterceptors(logger), info, func(ctx context.Context, req any) (any, error) {
+		assert.Equal(t, "generated", gerpc.RequestIDFromContext(ctx))
+		panic("boom")
+	})
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Len(t, status.Convert(err).Details(), 2, "ErrorInfo and DebugInfo")
+
+	info = &grpc.UnaryServerInfo{FullMethod: "/test.Service/Skipped"}
+	_, err = chainUnary(context.Background(), nil, chain.UnaryInterceptors(logger), info, func(ctx context.Context, req any) (any, error) {
+		return nil, nil
+	})
+	assert.NoError(t, err)
+
+	records := decodeRecords(t, buf)
+	require.Len(t, records, 2)
+	assert.Equal(t, "panic recovered in gRPC handler", records[0]["msg"])
+	assert.Equal(t, "/test.Service/Method", records[1]["method"])
+}
+
+func TestInterceptorChain_RecoversInsertedInterceptors(t *testing.T) {
+	logger, buf := newJSONLogger()
+	panicking := func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		panic("broken interceptor")
+	}
+	chain := gerpc.NewInterceptorChain().InsertBefore(gerpc.StageLogging, panicking, nil)
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}
+	_, err := chainUnary(context.Background(), nil, chain.UnaryInterceptors(logger), info, func(ctx context.Context, req any) (any, error) {
+		return nil, nil
+	})
+	assert.Equal(t, codes.Internal, status.Code(err))
+
+	// Recovered outside the logging stage, so only the panic is logged
+	records := decodeRecords(t, buf)
+	require.Len(t, records, 1)
+	assert.Equal(t, "panic recovered in gRPC handler", records[0]["msg"])
+	assert.Equal(t, "broken interceptor", records[0]["panic_value"])
+}
+
+type createUserRequest struct {
+	Email string `validate:"required,email"`
+}
+
+func TestInterceptorChain_LogsRejectedRequest(t *testing.T) {
+	logger, buf := newJSONLogger()
+	chain := gerpc.NewInterceptorChain()
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}
+	_, err := chainUnary(context.Background(), &createUserRequest{Email: "alice"}, chain.UnaryInterceptors(logger), info, func(ctx context.Context, req any) (any, error) {
+		t.Fatal("handler called with an invalid request")
+		return nil, nil
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	records := decodeRecords(t, buf)
+	require.Len(t, records, 1)
+	assert.Equal(t, "InvalidArgument", records[0]["code"])
+
+	chain.WithValidationOptions(gerpc.WithStructValidator(nil))
+	_, err = chainUnary(context.Background(), &createUserRequest{Email: "alice"}, chain.UnaryInterceptors(logger), info, func(ctx context.Context, req any) (any, error) {
+		return nil, nil
+	})
+	assert.NoError(t, err)
+}
+
+func TestGrpcServer_WithDefaultInterceptors(t *testing.T) {
+	logger, buf := newJSONLogger()
+	address := freeAddress(t)
+	server := gerpc.NewGrpcServer().
+		WithLogger(logger).
+		WithAddress(address).
+		WithDefaultInterceptors().
+		WithRegisterSrvFunc(func(*grpc.Server) error { return nil })
+	startServer(t, server)
+
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	var header metadata.MD
+	_, err = grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{}, grpc.Header(&header), grpc.WaitForReady(true))
+	require.NoError(t, err)
+	requestID := header.Get(gerpc.RequestIDKey)
+	require.Len(t, requestID, 1)
+
+	assert.Eventually(t, func() bool {
+		return bytes.Contains(buf.Bytes(), []byte(`"request_id":"`+requestID[0]+`"`))
+	}, time.Second, 10*time.Millisecond)
+}
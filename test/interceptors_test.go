@@ -1,10 +1,20 @@
 package gerpc_test
 
 import (
+	"context"
+	"log/slog"
 	"testing"
+	"time"
 
 	"github.com/itsLeonB/gerpc"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
 )
 
 func TestNewErrorInterceptor(t *testing.T) {
@@ -20,3 +30,146 @@ func TestNewLoggingInterceptor(t *testing.T) {
 
 	assert.NotNil(t, interceptor)
 }
+
+func TestNewStreamErrorInterceptor(t *testing.T) {
+	logger := &MockLogger{}
+	interceptor := gerpc.NewStreamErrorInterceptor(logger)
+
+	assert.NotNil(t, interceptor)
+}
+
+func TestNewStreamLoggingInterceptor(t *testing.T) {
+	logger := &MockLogger{}
+	interceptor := gerpc.NewStreamLoggingInterceptor(logger)
+
+	assert.NotNil(t, interceptor)
+}
+
+func TestNewRecoveryInterceptor(t *testing.T) {
+	logger := &MockLogger{}
+
+	assert.NotNil(t, gerpc.NewRecoveryInterceptor(logger))
+	assert.NotNil(t, gerpc.NewStreamRecoveryInterceptor(logger, gerpc.WithDevelopmentMode()))
+}
+
+func TestNewClientErrorInterceptor(t *testing.T) {
+	assert.NotNil(t, gerpc.NewClientErrorInterceptor())
+	assert.NotNil(t, gerpc.NewStreamClientErrorInterceptor())
+}
+
+func TestNewErrorInterceptor_WithErrorMapper(t *testing.T) {
+	logger := &MockLogger{}
+	mapper := func(err error) error { return nil }
+
+	assert.NotNil(t, gerpc.NewErrorInterceptor(logger, gerpc.WithErrorMapper(mapper)))
+	assert.NotNil(t, gerpc.NewStreamErrorInterceptor(logger, gerpc.WithErrorMapper(mapper)))
+}
+
+func TestNewErrorInterceptor_WithDebugOptions(t *testing.T) {
+	logger := &MockLogger{}
+	registry := prometheus.NewRegistry()
+	opts := []gerpc.ErrorInterceptorOption{
+		gerpc.WithPanicMetrics(registry),
+		gerpc.WithDevelopmentMode(),
+		gerpc.WithDevelopmentModeFromEnv("APP_DEBUG"),
+	}
+
+	// Both interceptors share the counter registered on the same registry
+	assert.NotNil(t, gerpc.NewErrorInterceptor(logger, opts...))
+	assert.NotNil(t, gerpc.NewStreamErrorInterceptor(logger, opts...))
+}
+
+func TestNewLoggingInterceptor_WithOptions(t *testing.T) {
+	logger := &MockLogger{}
+	opts := []gerpc.LoggingInterceptorOption{
+		gerpc.WithSkipMethods("/grpc.health.v1.Health/Check"),
+		gerpc.WithSkipPrefixes("/grpc.reflection."),
+		gerpc.WithCodeLevel(slog.LevelWarn, codes.NotFound),
+		gerpc.WithSlowThreshold(time.Second),
+	}
+
+	assert.NotNil(t, gerpc.NewLoggingInterceptor(logger, opts...))
+	assert.NotNil(t, gerpc.NewStreamLoggingInterceptor(logger, opts...))
+}
+
+func TestNewValidationInterceptor(t *testing.T) {
+	opts := []gerpc.ValidationInterceptorOption{
+		gerpc.WithProtoValidator(nil),
+		gerpc.WithStructValidator(nil),
+	}
+
+	assert.NotNil(t, gerpc.NewValidationInterceptor())
+	assert.NotNil(t, gerpc.NewStreamValidationInterceptor())
+	assert.NotNil(t, gerpc.NewValidationInterceptor(opts...))
+	assert.NotNil(t, gerpc.NewStreamValidationInterceptor(opts...))
+}
+
+func TestNewRequestIDInterceptor_EchoesHeader(t *testing.T) {
+	address := freeAddress(t)
+	server := gerpc.NewGrpcServer().
+		WithLogger(newRunLogger()).
+		WithAddress(address).
+		WithOpts(
+			grpc.ChainUnaryInterceptor(gerpc.NewRequestIDInterceptor(gerpc.WithRequestIDGenerator(func() string {
+				return "generated"
+			}))),
+			grpc.ChainStreamInterceptor(gerpc.NewStreamRequestIDInterceptor()),
+		).
+		WithRegisterSrvFunc(func(*grpc.Server) error { return nil })
+	startServer(t, server)
+
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+	client := grpc_health_v1.NewHealthClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	var header metadata.MD
+	_, err = client.Check(ctx, &grpc_health_v1.HealthCheckRequest{}, grpc.Header(&header), grpc.WaitForReady(true))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"generated"}, header.Get(gerpc.RequestIDKey))
+
+	ctx = metadata.AppendToOutgoingContext(ctx, gerpc.RequestIDKey, "req-1")
+	_, err = client.Check(ctx, &grpc_health_v1.HealthCheckRequest{}, grpc.Header(&header))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"req-1"}, header.Get(gerpc.RequestIDKey))
+}
+
+func TestNewClientRequestIDInterceptor_Propagates(t *testing.T) {
+	address := freeAddress(t)
+	server := gerpc.NewGrpcServer().
+		WithLogger(newRunLogger()).
+		WithAddress(address).
+		WithOpts(grpc.ChainUnaryInterceptor(gerpc.NewRequestIDInterceptor())).
+		WithRegisterSrvFunc(func(*grpc.Server) error { return nil })
+	startServer(t, server)
+
+	conn, err := grpc.NewClient(address,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(gerpc.NewClientRequestIDInterceptor()),
+		grpc.WithChainStreamInterceptor(gerpc.NewStreamClientRequestIDInterceptor()),
+	)
+	require.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+	client := grpc_health_v1.NewHealthClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	// The context of a handler that received the ID from an upstream service
+	ctx = gerpc.ContextWithRequestID(ctx, "upstream-1")
+
+	var header metadata.MD
+	_, err = client.Check(ctx, &grpc_health_v1.HealthCheckRequest{}, grpc.Header(&header), grpc.WaitForReady(true))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"upstream-1"}, header.Get(gerpc.RequestIDKey))
+}
+
+func TestRequestIDFromContext(t *testing.T) {
+	assert.Empty(t, gerpc.RequestIDFromContext(context.Background()))
+
+	ctx := gerpc.ContextWithRequestID(context.Background(), "req-1")
+	assert.Equal(t, "req-1", gerpc.RequestIDFromContext(ctx))
+}
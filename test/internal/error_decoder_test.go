@@ -0,0 +1,195 @@
+package internal_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/itsLeonB/gerpc/internal"
+	"github.com/itsLeonB/ungerr"
+	"github.com/rotisserie/eris"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func roundTrip(t *testing.T, handlerErr error) ungerr.AppError {
+	interceptor := internal.NewErrorInterceptor(&MockLogger{})
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, handlerErr
+	}
+
+	_, err := interceptor.Handle(context.Background(), nil, &grpc.UnaryServerInfo{}, handler)
+	assert.Error(t, err)
+
+	return internal.FromStatus(err)
+}
+
+func TestFromStatus_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		appErr ungerr.AppError
+	}{
+		{"bad request", ungerr.BadRequestError("bad input")},
+		{"unauthorized", ungerr.UnauthorizedError("missing token")},
+		{"forbidden", ungerr.ForbiddenError("not allowed")},
+		{"not found", ungerr.NotFoundError("user not found")},
+		{"conflict", ungerr.ConflictError("already exists")},
+		{"unprocessable entity", ungerr.UnprocessableEntityError("cannot process")},
+		{"structured details", ungerr.BadRequestError([]string{"first", "second"})},
+		{"internal", ungerr.InternalServerError()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			appErr := roundTrip(t, tt.appErr)
+
+			assert.Equal(t, tt.appErr.HttpStatus(), appErr.HttpStatus())
+			assert.Equal(t, tt.appErr.GrpcStatus(), appErr.GrpcStatus())
+			assert.Equal(t, tt.appErr.Error(), appErr.Error())
+			assert.Equal(t, tt.appErr.Details(), appErr.Details())
+			assert.Equal(t, codes.Code(tt.appErr.GrpcStatus()), status.Code(appErr))
+		})
+	}
+}
+
+func TestFromStatus_KeepsStatus(t *testing.T) {
+	for _, code := range []codes.Code{
+		codes.InvalidArgument, codes.OutOfRange, codes.Unauthenticated, codes.PermissionDenied,
+		codes.NotFound, codes.AlreadyExists, codes.Aborted, codes.FailedPrecondition, codes.Internal,
+	} {
+		t.Run(code.String(), func(t *testing.T) {
+			original := status.New(code, "downstream failed")
+			appErr := internal.FromStatus(original.Err())
+
+			assert.Equal(t, code, status.Code(appErr))
+			assert.Equal(t, uint32(code), appErr.GrpcStatus())
+			assert.Equal(t, original.Proto(), status.Convert(appErr).Proto())
+		})
+	}
+}
+
+func TestFromStatus_ValidationError(t *testing.T) {
+	validate := validator.New()
+	type testStruct struct {
+		Email string `validate:"required,email"`
+	}
+
+	appErr := roundTrip(t, eris.Wrap(validate.Struct(&testStruct{Email: "invalid"}), "validation failed"))
+
+	assert.Equal(t, http.StatusUnprocessableEntity, appErr.HttpStatus())
+	assert.Len(t, appErr.Details(), 1)
+
+	// Propagating the restored error keeps the field violations
+	_, err := internal.NewErrorInterceptor(&MockLogger{}).Handle(context.Background(), nil, &grpc.UnaryServerInfo{},
+		func(ctx context.Context, req interface{}) (interface{}, error) { return nil, appErr })
+
+	st := status.Convert(err)
+	var badRequest *errdetails.BadRequest
+	for _, detail := range st.Details() {
+		if detail, ok := detail.(*errdetails.BadRequest); ok {
+			badRequest = detail
+		}
+	}
+	assert.NotNil(t, badRequest)
+	assert.Equal(t, "testStruct.Email", badRequest.GetFieldViolations()[0].GetField())
+}
+
+func TestFromStatus_ValidationParams(t *testing.T) {
+	validate := validator.New()
+	type testStruct struct {
+		Name string `validate:"min=3"`
+	}
+
+	appErr := roundTrip(t, eris.Wrap(validate.Struct(&testStruct{Name: "ab"}), "validation failed"))
+
+	params, ok := appErr.(interface{ FieldParams() map[string]string })
+	assert.True(t, ok)
+	assert.Equal(t, map[string]string{"testStruct.Name": "3"}, params.FieldParams())
+}
+
+func TestFromStatus_PlainStatus(t *testing.T) {
+	appErr := internal.FromStatus(status.Error(codes.NotFound, "no such user"))
+
+	assert.Equal(t, http.StatusNotFound, appErr.HttpStatus())
+	assert.Equal(t, "no such user", appErr.Details())
+
+	appErr = internal.FromStatus(status.Error(codes.Unavailable, "unavailable"))
+	assert.Equal(t, http.StatusInternalServerError, appErr.HttpStatus())
+}
+
+func TestFromStatus_KeepsCodesWithoutAppError(t *testing.T) {
+	for _, code := range []codes.Code{
+		codes.DeadlineExceeded, codes.Canceled, codes.Unavailable,
+		codes.ResourceExhausted, codes.Unimplemented, codes.Unknown,
+	} {
+		t.Run(code.String(), func(t *testing.T) {
+			appErr := internal.FromStatus(status.Error(code, "downstream failed"))
+
+			assert.Equal(t, http.StatusInternalServerError, appErr.HttpStatus())
+			assert.Equal(t, uint32(code), appErr.GrpcStatus())
+			assert.Equal(t, code, status.Code(appErr))
+			assert.Equal(t, "downstream failed", status.Convert(appErr).Message())
+
+			// A handler passing the decoded error up returns the original status
+			interceptor := internal.NewErrorInterceptor(&MockLogger{})
+			_, err := interceptor.Handle(context.Background(), nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req any) (any, error) {
+				return nil, appErr
+			})
+			st := status.Convert(err)
+			assert.Equal(t, code, st.Code())
+			assert.Equal(t, "downstream failed", st.Message())
+		})
+	}
+}
+
+func TestFromStatus_Nil(t *testing.T) {
+	assert.Nil(t, internal.FromStatus(nil))
+}
+
+func TestClientErrorInterceptor(t *testing.T) {
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return status.Error(codes.PermissionDenied, "denied")
+	}
+
+	err := internal.ClientErrorInterceptor(context.Background(), "/test.Service/Method", nil, nil, nil, invoker)
+
+	var appErr ungerr.AppError
+	assert.True(t, errors.As(err, &appErr))
+	assert.Equal(t, http.StatusForbidden, appErr.HttpStatus())
+}
+
+type mockClientStream struct {
+	grpc.ClientStream
+	recvErr error
+}
+
+func (m *mockClientStream) RecvMsg(any) error { return m.recvErr }
+
+func TestStreamClientErrorInterceptor(t *testing.T) {
+	recvErr := status.Error(codes.NotFound, "gone")
+	streamer := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return &mockClientStream{recvErr: recvErr}, nil
+	}
+
+	stream, err := internal.StreamClientErrorInterceptor(context.Background(), &grpc.StreamDesc{}, nil, "/test.Service/Stream", streamer)
+	assert.NoError(t, err)
+
+	err = stream.RecvMsg(nil)
+	appErr, ok := err.(ungerr.AppError)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusNotFound, appErr.HttpStatus())
+
+	streamer = func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return &mockClientStream{recvErr: io.EOF}, nil
+	}
+
+	stream, err = internal.StreamClientErrorInterceptor(context.Background(), &grpc.StreamDesc{}, nil, "/test.Service/Stream", streamer)
+	assert.NoError(t, err)
+	assert.Equal(t, io.EOF, stream.RecvMsg(nil))
+}
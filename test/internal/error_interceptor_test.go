@@ -4,17 +4,24 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
+	"log/slog"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/itsLeonB/gerpc/internal"
 	"github.com/itsLeonB/ungerr"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/rotisserie/eris"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
@@ -141,3 +148,708 @@ func TestErrorInterceptor_Handle_UnwrappedError(t *testing.T) {
 	assert.True(t, ok)
 	assert.Equal(t, codes.Internal, st.Code())
 }
+
+func TestErrorInterceptor_HandleStream_Success(t *testing.T) {
+	logger := &MockLogger{}
+	interceptor := internal.NewStreamErrorInterceptor(logger)
+
+	handler := func(srv any, ss grpc.ServerStream) error {
+		return ss.SendMsg("message")
+	}
+
+	stream := &MockServerStream{}
+	err := interceptor.HandleStream(nil, stream, &grpc.StreamServerInfo{}, handler)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []any{"message"}, stream.sent)
+}
+
+func TestErrorInterceptor_HandleStream_AppError(t *testing.T) {
+	logger := &MockLogger{}
+	interceptor := internal.NewStreamErrorInterceptor(logger)
+
+	handler := func(srv any, ss grpc.ServerStream) error {
+		return ungerr.NotFoundError("not found")
+	}
+
+	err := interceptor.HandleStream(nil, &MockServerStream{}, &grpc.StreamServerInfo{}, handler)
+
+	st, ok := status.FromError(err)
+	assert.True(t, ok)
+	assert.Equal(t, codes.NotFound, st.Code())
+}
+
+func TestErrorInterceptor_HandleStream_ValidationError(t *testing.T) {
+	logger := &MockLogger{}
+	interceptor := internal.NewStreamErrorInterceptor(logger)
+
+	validate := validator.New()
+	type testStruct struct {
+		Email string `validate:"required,email"`
+	}
+
+	handler := func(srv any, ss grpc.ServerStream) error {
+		return eris.Wrap(validate.Struct(&testStruct{Email: "invalid"}), "validation failed")
+	}
+
+	err := interceptor.HandleStream(nil, &MockServerStream{}, &grpc.StreamServerInfo{}, handler)
+
+	st, ok := status.FromError(err)
+	assert.True(t, ok)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+}
+
+func TestErrorInterceptor_HandleStream_Panic(t *testing.T) {
+	logger := &MockLogger{}
+	logger.On("Error", mock.Anything).Return()
+	logger.On("Errorf", mock.Anything, mock.Anything).Return()
+
+	interceptor := internal.NewStreamErrorInterceptor(logger)
+
+	handler := func(srv any, ss grpc.ServerStream) error {
+		panic("test panic")
+	}
+
+	info := &grpc.StreamServerInfo{FullMethod: "/test.Service/Stream"}
+	err := interceptor.HandleStream(nil, &MockServerStream{}, info, handler)
+
+	st, ok := status.FromError(err)
+	assert.True(t, ok)
+	assert.Equal(t, codes.Internal, st.Code())
+	logger.AssertCalled(t, "Errorf", "gRPC method: %s", "/test.Service/Stream")
+}
+
+func TestErrorInterceptor_HandleStream_MidStreamError(t *testing.T) {
+	logger := &MockLogger{}
+	interceptor := internal.NewStreamErrorInterceptor(logger)
+
+	sendErr := status.Error(codes.Unavailable, "transport is closing")
+	handler := func(srv any, ss grpc.ServerStream) error {
+		if err := ss.SendMsg("message"); err != nil {
+			return eris.Wrap(err, "error sending message")
+		}
+		return nil
+	}
+
+	err := interceptor.HandleStream(nil, &MockServerStream{sendErr: sendErr}, &grpc.StreamServerInfo{}, handler)
+
+	st, ok := status.FromError(err)
+	assert.True(t, ok)
+	assert.Equal(t, codes.Unavailable, st.Code())
+	assert.Equal(t, "transport is closing", st.Message())
+}
+
+func TestErrorInterceptor_HandleStream_ConcurrentSendRecv(t *testing.T) {
+	logger := &MockLogger{}
+	interceptor := internal.NewStreamErrorInterceptor(logger)
+
+	sendErr := status.Error(codes.Unavailable, "transport is closing")
+	recvErr := status.Error(codes.Canceled, "context canceled")
+	handler := func(srv any, ss grpc.ServerStream) error {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				_ = ss.SendMsg("message")
+			}
+		}()
+		var err error
+		for range 100 {
+			err = ss.RecvMsg(nil)
+		}
+		wg.Wait()
+		return eris.Wrap(err, "error receiving message")
+	}
+
+	stream := &MockServerStream{sendErr: sendErr, recvErr: recvErr}
+	err := interceptor.HandleStream(nil, stream, &grpc.StreamServerInfo{}, handler)
+
+	st, ok := status.FromError(err)
+	assert.True(t, ok)
+	assert.Equal(t, codes.Canceled, st.Code())
+	assert.Equal(t, "context canceled", st.Message())
+}
+
+func TestErrorInterceptor_HandleStream_RecvEOF(t *testing.T) {
+	logger := &MockLogger{}
+	interceptor := internal.NewStreamErrorInterceptor(logger)
+
+	handler := func(srv any, ss grpc.ServerStream) error {
+		if err := ss.RecvMsg(nil); err != io.EOF {
+			return err
+		}
+		return eris.Wrap(io.EOF, "client closed stream")
+	}
+
+	err := interceptor.HandleStream(nil, &MockServerStream{recvErr: io.EOF}, &grpc.StreamServerInfo{}, handler)
+
+	st, ok := status.FromError(err)
+	assert.True(t, ok)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+}
+
+func TestErrorInterceptor_Handle_ErrorInfoDetails(t *testing.T) {
+	logger := &MockLogger{}
+	interceptor := internal.NewErrorInterceptor(logger)
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, ungerr.NotFoundError("user not found")
+	}
+
+	_, err := interceptor.Handle(context.Background(), nil, &grpc.UnaryServerInfo{}, handler)
+
+	st, ok := status.FromError(err)
+	assert.True(t, ok)
+	assert.Equal(t, codes.NotFound, st.Code())
+
+	details := st.Details()
+	assert.Len(t, details, 1)
+	info, ok := details[0].(*errdetails.ErrorInfo)
+	assert.True(t, ok)
+	assert.Equal(t, "NOT_FOUND", info.GetReason())
+	assert.Equal(t, internal.ErrorDomain, info.GetDomain())
+	assert.Equal(t, "user not found", info.GetMetadata()[internal.ErrorInfoDetailsKey])
+}
+
+func TestErrorInterceptor_Handle_FieldViolations(t *testing.T) {
+	logger := &MockLogger{}
+	interceptor := internal.NewErrorInterceptor(logger)
+
+	validate := validator.New()
+	type testStruct struct {
+		Email string `validate:"required,email"`
+		Name  string `validate:"min=3"`
+	}
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, eris.Wrap(validate.Struct(&testStruct{Email: "invalid", Name: "ab"}), "validation failed")
+	}
+
+	_, err := interceptor.Handle(context.Background(), nil, &grpc.UnaryServerInfo{}, handler)
+
+	st, ok := status.FromError(err)
+	assert.True(t, ok)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+
+	var badRequest *errdetails.BadRequest
+	var errorInfo *errdetails.ErrorInfo
+	for _, detail := range st.Details() {
+		switch detail := detail.(type) {
+		case *errdetails.BadRequest:
+			badRequest = detail
+		case *errdetails.ErrorInfo:
+			errorInfo = detail
+		}
+	}
+
+	assert.NotNil(t, errorInfo)
+	assert.Equal(t, "UNPROCESSABLE_ENTITY", errorInfo.GetReason())
+
+	assert.NotNil(t, badRequest)
+	violations := badRequest.GetFieldViolations()
+	assert.Len(t, violations, 2)
+	assert.Equal(t, "testStruct.Email", violations[0].GetField())
+	assert.Equal(t, "email", violations[0].GetReason())
+	assert.Equal(t, "testStruct.Name", violations[1].GetField())
+	assert.Equal(t, "min", violations[1].GetReason())
+	assert.NotContains(t, violations[1].GetDescription(), "param")
+	assert.JSONEq(t, `{"testStruct.Name":"3"}`, errorInfo.GetMetadata()[internal.ErrorInfoFieldParamsKey])
+	for key := range errorInfo.GetMetadata() {
+		assert.Regexp(t, `^[a-z][a-zA-Z0-9-_]{0,63}$`, key)
+	}
+}
+
+func TestErrorInterceptor_Handle_ContextErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		handlerErr error
+		code       codes.Code
+	}{
+		{"canceled", context.Canceled, codes.Canceled},
+		{"wrapped canceled", eris.Wrap(context.Canceled, "query aborted"), codes.Canceled},
+		{"fmt wrapped canceled", fmt.Errorf("query aborted: %w", context.Canceled), codes.Canceled},
+		{"deadline exceeded", context.DeadlineExceeded, codes.DeadlineExceeded},
+		{"wrapped deadline exceeded", eris.Wrap(context.DeadlineExceeded, "query timed out"), codes.DeadlineExceeded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := &MockLogger{}
+			logger.On("Warnf", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+
+			interceptor := internal.NewErrorInterceptor(logger)
+
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				return nil, tt.handlerErr
+			}
+
+			_, err := interceptor.Handle(context.Background(), nil, &grpc.UnaryServerInfo{}, handler)
+
+			st, ok := status.FromError(err)
+			assert.True(t, ok)
+			assert.Equal(t, tt.code, st.Code())
+			logger.AssertExpectations(t)
+		})
+	}
+}
+
+func TestErrorInterceptor_Handle_ContextCauseLogged(t *testing.T) {
+	logger := &MockLogger{}
+	logger.On("Warnf", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+
+	interceptor := internal.NewErrorInterceptor(logger)
+
+	cause := errors.New("client went away")
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cancel(cause)
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, ctx.Err()
+	}
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}
+	_, err := interceptor.Handle(ctx, nil, info, handler)
+
+	assert.Equal(t, codes.Canceled, status.Code(err))
+	logger.AssertCalled(t, "Warnf", "gRPC method %s stopped: %v (cause: %v)", "/test.Service/Method", context.Canceled, cause)
+}
+
+type insufficientFundsError struct{}
+
+func (insufficientFundsError) Error() string { return "insufficient funds" }
+
+func TestErrorInterceptor_Handle_ErrorMappers(t *testing.T) {
+	errNoRows := errors.New("no rows in result set")
+
+	notFoundMapper := func(err error) error {
+		if errors.Is(err, errNoRows) {
+			return ungerr.NotFoundError("record not found")
+		}
+		return nil
+	}
+	fundsMapper := func(err error) error {
+		var fundsErr insufficientFundsError
+		if errors.As(err, &fundsErr) {
+			return status.Error(codes.FailedPrecondition, fundsErr.Error())
+		}
+		return nil
+	}
+	shadowedMapper := func(err error) error {
+		return ungerr.ConflictError("should not be reached")
+	}
+
+	interceptor := internal.NewErrorInterceptor(&MockLogger{},
+		internal.WithErrorMapper(notFoundMapper, fundsMapper),
+		internal.WithErrorMapper(shadowedMapper),
+	)
+
+	tests := []struct {
+		name       string
+		handlerErr error
+		code       codes.Code
+	}{
+		{"errors.Is mapper", eris.Wrap(errNoRows, "query failed"), codes.NotFound},
+		{"errors.As mapper", fmt.Errorf("charge: %w", insufficientFundsError{}), codes.FailedPrecondition},
+		{"later mapper", eris.New("anything else"), codes.AlreadyExists},
+		{"AppError bypasses mappers", ungerr.ForbiddenError("no"), codes.PermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				return nil, tt.handlerErr
+			}
+
+			_, err := interceptor.Handle(context.Background(), nil, &grpc.UnaryServerInfo{}, handler)
+
+			assert.Equal(t, tt.code, status.Code(err))
+		})
+	}
+}
+
+func TestErrorInterceptor_Handle_ErrorMapperFallsBack(t *testing.T) {
+	logger := &MockLogger{}
+	logger.On("Error", mock.Anything).Return()
+	logger.On("Errorf", mock.Anything, mock.Anything).Return()
+
+	interceptor := internal.NewErrorInterceptor(logger, internal.WithErrorMapper(func(err error) error {
+		return errors.New("not an AppError or status")
+	}))
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, eris.Wrap(io.EOF, "eof error")
+	}
+
+	_, err := interceptor.Handle(context.Background(), nil, &grpc.UnaryServerInfo{}, handler)
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestErrorInterceptor_Handle_WrappedAppError(t *testing.T) {
+	appErr := ungerr.NotFoundError("user not found")
+
+	tests := []struct {
+		name       string
+		handlerErr error
+	}{
+		{"fmt wrapped", fmt.Errorf("loading user: %w", appErr)},
+		{"eris wrapped", eris.Wrap(appErr, "loading user")},
+		{"double wrapped", fmt.Errorf("handler: %w", eris.Wrap(appErr, "loading user"))},
+		{"joined", errors.Join(errors.New("cache miss"), fmt.Errorf("loading user: %w", appErr))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := &MockLogger{}
+			logger.On("Infof", mock.Anything, mock.Anything, mock.Anything).Return()
+
+			interceptor := internal.NewErrorInterceptor(logger)
+
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				return nil, tt.handlerErr
+			}
+
+			info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}
+			_, err := interceptor.Handle(context.Background(), nil, info, handler)
+
+			st, ok := status.FromError(err)
+			assert.True(t, ok)
+			assert.Equal(t, codes.NotFound, st.Code())
+			assert.Equal(t, appErr.Error(), st.Message())
+			logger.AssertCalled(t, "Infof", "gRPC method %s returned a wrapped error: %v", "/test.Service/Method", tt.handlerErr)
+		})
+	}
+}
+
+func TestErrorInterceptor_Handle_WrappedStatus(t *testing.T) {
+	logger := &MockLogger{}
+	logger.On("Infof", mock.Anything, mock.Anything, mock.Anything).Return()
+
+	interceptor := internal.NewErrorInterceptor(logger)
+
+	downstreamErr := status.Error(codes.Unavailable, "inventory service unavailable")
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, eris.Wrap(fmt.Errorf("reserving stock: %w", downstreamErr), "placing order")
+	}
+
+	_, err := interceptor.Handle(context.Background(), nil, &grpc.UnaryServerInfo{}, handler)
+
+	st, ok := status.FromError(err)
+	assert.True(t, ok)
+	assert.Equal(t, codes.Unavailable, st.Code())
+	assert.Equal(t, "inventory service unavailable", st.Message())
+}
+
+type outerAppError struct {
+	ungerr.AppError
+	inner error
+}
+
+func (e outerAppError) Unwrap() error { return e.inner }
+
+func TestErrorInterceptor_Handle_InnermostAppError(t *testing.T) {
+	logger := &MockLogger{}
+	logger.On("Infof", mock.Anything, mock.Anything, mock.Anything).Return()
+
+	interceptor := internal.NewErrorInterceptor(logger)
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, outerAppError{ungerr.InternalServerError(), ungerr.ConflictError("duplicate")}
+	}
+
+	_, err := interceptor.Handle(context.Background(), nil, &grpc.UnaryServerInfo{}, handler)
+
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+}
+
+// recordFields turns the alternating key/value args of a structured record into a map
+func recordFields(args []any) map[string]any {
+	fields := make(map[string]any, len(args)/2)
+	for i := 0; i+1 < len(args); i += 2 {
+		fields[args[i].(string)] = args[i+1]
+	}
+	return fields
+}
+
+func TestErrorInterceptor_Handle_StructuredPanic(t *testing.T) {
+	var nilMap map[string]*int
+	var nilSlice []int
+	index := 5
+
+	tests := []struct {
+		name           string
+		panicFunc      func()
+		classification string
+	}{
+		{"nil dereference", func() { _ = *nilMap["missing"] }, "nil_dereference"},
+		{"index out of range", func() { _ = nilSlice[index] }, "index_out_of_range"},
+		{"slice bounds out of range", func() { _ = nilSlice[:index] }, "slice_bounds_out_of_range"},
+		{"string panic", func() { panic("something broke") }, "string_panic"},
+		{"unknown", func() { panic(42) }, "unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := &MockLeveledLogger{}
+			logger.On("Log", mock.Anything, slog.LevelError, "panic recovered in gRPC handler", mock.Anything).Return()
+
+			interceptor := internal.NewErrorInterceptor(logger)
+
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				tt.panicFunc()
+				return nil, nil
+			}
+
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-request-id", "req-123"))
+			info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method", Server: &testInterceptor{}}
+			_, err := interceptor.Handle(ctx, nil, info, handler)
+
+			assert.Equal(t, codes.Internal, status.Code(err))
+			logger.AssertNumberOfCalls(t, "Log", 1)
+			logger.AssertNotCalled(t, "Error", mock.Anything)
+
+			fields := recordFields(logger.Calls[0].Arguments.Get(3).([]any))
+			assert.Equal(t, "/test.Service/Method", fields["method"])
+			assert.Equal(t, "*internal_test.testInterceptor", fields["server"])
+			assert.Equal(t, "req-123", fields["request_id"])
+			assert.Equal(t, tt.classification, fields["classification"])
+			assert.NotEmpty(t, fields["panic_value"])
+			assert.NotEmpty(t, fields["panic_type"])
+			assert.Contains(t, fields["stack"], "runtime/debug.Stack")
+		})
+	}
+}
+
+func TestErrorInterceptor_Handle_StructuredErrors(t *testing.T) {
+	tests := []struct {
+		name           string
+		handlerErr     error
+		msg            string
+		classification string
+	}{
+		{"unwrapped", errors.New("unwrapped error"), "unwrapped error detected, add eris.Wrap() or return ungerr.AppError", "unwrapped_error"},
+		{"unhandled", eris.Wrap(errors.New("query failed"), "unhandled error"), "unhandled error", "unhandled_error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := &MockLeveledLogger{}
+			logger.On("Log", mock.Anything, slog.LevelError, tt.msg, mock.Anything).Return()
+
+			interceptor := internal.NewErrorInterceptor(logger)
+
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				return nil, tt.handlerErr
+			}
+
+			info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}
+			_, err := interceptor.Handle(context.Background(), nil, info, handler)
+
+			assert.Equal(t, codes.Internal, status.Code(err))
+			logger.AssertNumberOfCalls(t, "Log", 1)
+
+			fields := recordFields(logger.Calls[0].Arguments.Get(3).([]any))
+			assert.Equal(t, "/test.Service/Method", fields["method"])
+			assert.Equal(t, tt.classification, fields["classification"])
+			assert.Equal(t, tt.handlerErr.Error(), fields["error"])
+			assert.NotContains(t, fields, "request_id")
+		})
+	}
+}
+
+func TestErrorInterceptor_Handle_PrefixesRequestID(t *testing.T) {
+	logger := &MockLogger{}
+	logger.On("Error", mock.Anything, mock.Anything).Return()
+	logger.On("Errorf", mock.Anything, mock.Anything).Return()
+
+	interceptor := internal.NewErrorInterceptor(logger)
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, errors.New("plain error")
+	}
+
+	ctx := internal.ContextWithRequestID(context.Background(), "req-%1")
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}
+	_, err := interceptor.Handle(ctx, nil, info, handler)
+	assert.Error(t, err)
+
+	assert.NotEmpty(t, logger.Calls)
+	for _, call := range logger.Calls {
+		switch call.Method {
+		case "Error":
+			assert.Equal(t, "[request_id=req-%1] ", call.Arguments.Get(0))
+		case "Errorf":
+			assert.True(t, strings.HasPrefix(call.Arguments.String(0), "[request_id=req-%%1] "), call.Arguments.String(0))
+		}
+	}
+}
+
+func TestErrorInterceptor_Handle_IgnoresInvalidIncomingRequestID(t *testing.T) {
+	for _, id := range []string{"has space", "ünicode", strings.Repeat("a", 129)} {
+		logger := &MockLeveledLogger{}
+		logger.On("Log", mock.Anything, slog.LevelError, "unhandled error", mock.Anything).Return()
+
+		interceptor := internal.NewErrorInterceptor(logger)
+
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, eris.Wrap(errors.New("query failed"), "unhandled error")
+		}
+
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-request-id", id))
+		_, err := interceptor.Handle(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}, handler)
+		assert.Error(t, err)
+
+		fields := recordFields(logger.Calls[0].Arguments.Get(3).([]any))
+		assert.NotContains(t, fields, "request_id", id)
+	}
+}
+
+func debugInfo(st *status.Status) *errdetails.DebugInfo {
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.DebugInfo); ok {
+			return info
+		}
+	}
+	return nil
+}
+
+func TestErrorInterceptor_Handle_PanicMetrics(t *testing.T) {
+	logger := &MockLogger{}
+	logger.On("Error", mock.Anything).Return()
+	logger.On("Errorf", mock.Anything, mock.Anything).Return()
+	logger.On("Errorf", mock.Anything, mock.Anything, mock.Anything).Return()
+
+	registry := prometheus.NewRegistry()
+	interceptor := internal.NewErrorInterceptor(logger, internal.WithPanicMetrics(registry))
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}
+
+	for _, panicFunc := range []func(){
+		func() { panic("boom") },
+		func() { panic("boom again") },
+		func() {
+			var m map[string]int
+			m["key"] = 1
+		},
+	} {
+		_, err := interceptor.Handle(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			panicFunc()
+			return nil, nil
+		})
+		assert.Equal(t, codes.Internal, status.Code(err))
+	}
+
+	assert.Equal(t, float64(2), gatherMetric(t, registry, "grpc_server_panics_total", map[string]string{"method": info.FullMethod, "kind": "string_panic"}).GetCounter().GetValue())
+	assert.Equal(t, float64(1), gatherMetric(t, registry, "grpc_server_panics_total", map[string]string{"method": info.FullMethod, "kind": "runtime_error"}).GetCounter().GetValue())
+}
+
+func TestErrorInterceptor_Handle_PanicDebugInfo(t *testing.T) {
+	logger := &MockLogger{}
+	logger.On("Error", mock.Anything).Return()
+	logger.On("Errorf", mock.Anything, mock.Anything).Return()
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		panic("test panic")
+	}
+
+	// Off by default
+	_, err := internal.NewErrorInterceptor(logger).Handle(context.Background(), nil, &grpc.UnaryServerInfo{}, handler)
+	assert.Nil(t, debugInfo(status.Convert(err)))
+
+	_, err = internal.NewErrorInterceptor(logger, internal.WithDevelopmentMode()).Handle(context.Background(), nil, &grpc.UnaryServerInfo{}, handler)
+	st := status.Convert(err)
+	assert.Equal(t, codes.Internal, st.Code())
+
+	info := debugInfo(st)
+	if assert.NotNil(t, info) {
+		assert.Equal(t, "panic (string_panic): test panic", info.GetDetail())
+		assert.NotEmpty(t, info.GetStackEntries())
+		assert.Contains(t, strings.Join(info.GetStackEntries(), "\n"), "error_interceptor_test.go")
+	}
+}
+
+func TestErrorInterceptor_HandleStream_PanicDebugInfo(t *testing.T) {
+	logger := &MockLogger{}
+	logger.On("Error", mock.Anything).Return()
+	logger.On("Errorf", mock.Anything, mock.Anything).Return()
+
+	interceptor := internal.NewStreamErrorInterceptor(logger, internal.WithDevelopmentMode())
+	err := interceptor.HandleStream(nil, &MockServerStream{}, &grpc.StreamServerInfo{}, func(srv any, ss grpc.ServerStream) error {
+		panic("test panic")
+	})
+
+	assert.NotNil(t, debugInfo(status.Convert(err)))
+}
+
+func TestErrorInterceptor_Handle_MaskedErrorDebugInfo(t *testing.T) {
+	logger := &MockLogger{}
+	logger.On("Error", mock.Anything).Return()
+	logger.On("Errorf", mock.Anything, mock.Anything).Return()
+
+	tests := []struct {
+		name   string
+		err    error
+		detail string
+		stack  string
+	}{
+		{"eris wrapped", eris.Wrap(errors.New("database unavailable"), "error loading user"), "error loading user: database unavailable", "error_interceptor_test.go"},
+		{"unwrapped", errors.New("database unavailable"), "database unavailable", "database unavailable"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				return nil, tt.err
+			}
+
+			// Off by default
+			_, err := internal.NewErrorInterceptor(logger).Handle(context.Background(), nil, &grpc.UnaryServerInfo{}, handler)
+			st := status.Convert(err)
+			assert.Equal(t, "Internal Server Error", st.Message())
+			assert.Nil(t, debugInfo(st))
+
+			_, err = internal.NewErrorInterceptor(logger, internal.WithDevelopmentMode()).Handle(context.Background(), nil, &grpc.UnaryServerInfo{}, handler)
+			st = status.Convert(err)
+			assert.Equal(t, codes.Internal, st.Code())
+			assert.Equal(t, "Internal Server Error", st.Message())
+
+			info := debugInfo(st)
+			if assert.NotNil(t, info) {
+				assert.Equal(t, tt.detail, info.GetDetail())
+				assert.Contains(t, strings.Join(info.GetStackEntries(), "\n"), tt.stack)
+			}
+		})
+	}
+}
+
+func TestErrorInterceptor_WithDevelopmentModeFromEnv(t *testing.T) {
+	logger := &MockLogger{}
+	logger.On("Error", mock.Anything).Return()
+	logger.On("Errorf", mock.Anything, mock.Anything).Return()
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, errors.New("database unavailable")
+	}
+
+	tests := []struct {
+		value   string
+		enabled bool
+	}{
+		{"", false},
+		{"false", false},
+		{"yes", false},
+		{"true", true},
+		{"1", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Setenv("GERPC_TEST_DEV_MODE", tt.value)
+
+			interceptor := internal.NewErrorInterceptor(logger, internal.WithDevelopmentModeFromEnv("GERPC_TEST_DEV_MODE"))
+			_, err := interceptor.Handle(context.Background(), nil, &grpc.UnaryServerInfo{}, handler)
+
+			assert.Equal(t, tt.enabled, debugInfo(status.Convert(err)) != nil)
+		})
+	}
+}
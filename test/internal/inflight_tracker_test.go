@@ -0,0 +1,41 @@
+package internal_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/itsLeonB/gerpc/internal"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+func TestInFlightTracker_Handle(t *testing.T) {
+	tracker := internal.NewInFlightTracker()
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		assert.Equal(t, map[string]int{"/test.Service/Method": 1}, tracker.Snapshot())
+		return "success", nil
+	}
+
+	resp, err := tracker.Handle(context.Background(), nil, info, handler)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "success", resp)
+	assert.Empty(t, tracker.Snapshot())
+}
+
+func TestInFlightTracker_HandleStream(t *testing.T) {
+	tracker := internal.NewInFlightTracker()
+	info := &grpc.StreamServerInfo{FullMethod: "/test.Service/Stream"}
+
+	handler := func(srv any, ss grpc.ServerStream) error {
+		assert.Equal(t, map[string]int{"/test.Service/Stream": 1}, tracker.Snapshot())
+		return nil
+	}
+
+	err := tracker.HandleStream(nil, nil, info, handler)
+
+	assert.NoError(t, err)
+	assert.Empty(t, tracker.Snapshot())
+}
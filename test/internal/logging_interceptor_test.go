@@ -2,15 +2,27 @@ package internal_test
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"errors"
+	"log/slog"
+	"net"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/itsLeonB/gerpc/internal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 func TestLoggingInterceptor_Handle_Success(t *testing.T) {
@@ -66,3 +78,266 @@ func TestLoggingInterceptor_Handle_NonGRPCError(t *testing.T) {
 	assert.Equal(t, testErr, err)
 	logger.AssertExpectations(t)
 }
+
+func TestLoggingInterceptor_HandleStream_Success(t *testing.T) {
+	logger := &MockLogger{}
+	logger.On("Infof", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+
+	interceptor := internal.NewStreamLoggingInterceptor(logger)
+
+	msg := wrapperspb.String("hello")
+	handler := func(srv any, ss grpc.ServerStream) error {
+		if err := ss.RecvMsg(msg); err != nil {
+			return err
+		}
+		for i := 0; i < 2; i++ {
+			if err := ss.SendMsg(msg); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	info := &grpc.StreamServerInfo{FullMethod: "/test.Service/Stream", IsClientStream: true, IsServerStream: true}
+	err := interceptor.HandleStream(nil, &MockServerStream{}, info, handler)
+
+	assert.NoError(t, err)
+	size := proto.Size(msg)
+	logger.AssertCalled(t, "Infof",
+		"[gRPC] method=%s type=%s duration=%s sent=%d received=%d bytes_sent=%d bytes_received=%d status=OK",
+		"/test.Service/Stream", "bidi", mock.Anything, 2, 1, 2*size, size,
+	)
+}
+
+func TestLoggingInterceptor_HandleStream_Error(t *testing.T) {
+	logger := &MockLogger{}
+	logger.On("Errorf", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+
+	interceptor := internal.NewStreamLoggingInterceptor(logger)
+
+	testErr := status.Error(codes.Unavailable, "test error")
+	handler := func(srv any, ss grpc.ServerStream) error {
+		return ss.SendMsg(wrapperspb.String("hello"))
+	}
+
+	info := &grpc.StreamServerInfo{FullMethod: "/test.Service/Stream", IsServerStream: true}
+	err := interceptor.HandleStream(nil, &MockServerStream{sendErr: testErr}, info, handler)
+
+	assert.Equal(t, testErr, err)
+	logger.AssertCalled(t, "Errorf",
+		"[gRPC] method=%s type=%s duration=%v sent=%d received=%d bytes_sent=%d bytes_received=%d status=%s msg=%q err=%v",
+		"/test.Service/Stream", "server", mock.Anything, 0, 0, 0, 0, "Unavailable", "test error", testErr,
+	)
+}
+
+func TestLoggingInterceptor_Handle_SkipMethods(t *testing.T) {
+	logger := &MockLogger{}
+	interceptor := internal.NewLoggingInterceptor(logger,
+		internal.WithSkipMethods("/grpc.health.v1.Health/Check"),
+		internal.WithSkipPrefixes("/grpc.reflection."),
+	)
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "success", nil
+	}
+
+	for _, method := range []string{
+		"/grpc.health.v1.Health/Check",
+		"/grpc.reflection.v1.ServerReflection/ServerReflectionInfo",
+	} {
+		resp, err := interceptor.Handle(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "success", resp)
+	}
+	logger.AssertNotCalled(t, "Infof", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestLoggingInterceptor_HandleStream_SkipMethods(t *testing.T) {
+	logger := &MockLogger{}
+	interceptor := internal.NewStreamLoggingInterceptor(logger, internal.WithSkipPrefixes("/grpc.health.v1."))
+
+	called := false
+	handler := func(srv any, ss grpc.ServerStream) error {
+		called = true
+		return nil
+	}
+
+	info := &grpc.StreamServerInfo{FullMethod: "/grpc.health.v1.Health/Watch", IsServerStream: true}
+	err := interceptor.HandleStream(nil, &MockServerStream{}, info, handler)
+
+	assert.NoError(t, err)
+	assert.True(t, called)
+}
+
+func TestLoggingInterceptor_Handle_CodeLevel(t *testing.T) {
+	logger := &MockLogger{}
+	logger.On("Warnf", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+
+	interceptor := internal.NewLoggingInterceptor(logger,
+		internal.WithCodeLevel(slog.LevelWarn, codes.NotFound, codes.InvalidArgument),
+	)
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.NotFound, "missing")
+	}
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}
+	_, err := interceptor.Handle(context.Background(), nil, info, handler)
+
+	assert.Error(t, err)
+	logger.AssertExpectations(t)
+	logger.AssertNotCalled(t, "Errorf", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestLoggingInterceptor_Handle_CodeLevelDebug(t *testing.T) {
+	logger := &MockLogger{}
+	logger.On("Debugf", mock.Anything, mock.Anything, mock.Anything).Return()
+
+	interceptor := internal.NewLoggingInterceptor(logger, internal.WithCodeLevel(slog.LevelDebug, codes.OK))
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "success", nil
+	}
+
+	_, err := interceptor.Handle(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}, handler)
+
+	assert.NoError(t, err)
+	logger.AssertExpectations(t)
+}
+
+func TestLoggingInterceptor_Handle_SlowThreshold(t *testing.T) {
+	logger := &MockLogger{}
+	logger.On("Warnf", "[gRPC] method=%s duration=%s status=OK slow=true", mock.Anything, mock.Anything).Return()
+
+	interceptor := internal.NewLoggingInterceptor(logger, internal.WithSlowThreshold(time.Millisecond))
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		time.Sleep(5 * time.Millisecond)
+		return "success", nil
+	}
+
+	_, err := interceptor.Handle(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}, handler)
+
+	assert.NoError(t, err)
+	logger.AssertExpectations(t)
+}
+
+func TestLoggingInterceptor_Handle_SlowErrorStaysError(t *testing.T) {
+	logger := &MockLogger{}
+	logger.On("Errorf", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+
+	interceptor := internal.NewLoggingInterceptor(logger, internal.WithSlowThreshold(time.Millisecond))
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		time.Sleep(5 * time.Millisecond)
+		return nil, status.Error(codes.Internal, "boom")
+	}
+
+	_, err := interceptor.Handle(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}, handler)
+
+	assert.Error(t, err)
+	logger.AssertExpectations(t)
+}
+
+func TestLoggingInterceptor_Handle_CallerFields(t *testing.T) {
+	logger := &MockLogger{}
+	logger.On("Infof", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+
+	interceptor := internal.NewLoggingInterceptor(logger, internal.WithMetadataKeys("X-Tenant-ID"))
+
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: "billing-service"}}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		"user-agent", "grpc-go/1.75.0",
+		"x-tenant-id", "acme",
+		"authorization", "Bearer secret",
+	))
+	ctx = peer.NewContext(ctx, &peer.Peer{
+		Addr:     &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 5000},
+		AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}},
+	})
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return wrapperspb.String("pong"), nil
+	}
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}
+	_, err := interceptor.Handle(ctx, wrapperspb.String("ping"), info, handler)
+	assert.NoError(t, err)
+
+	call := logger.Calls[0]
+	assert.Equal(t,
+		"[gRPC] method=%s duration=%s status=OK peer=%v identity=%v user_agent=%v metadata.x-tenant-id=%v deadline_remaining_ms=%v request_bytes=%v response_bytes=%v",
+		call.Arguments.String(0),
+	)
+	assert.Equal(t, "10.0.0.1:5000", call.Arguments.Get(3))
+	assert.Equal(t, "billing-service", call.Arguments.Get(4))
+	assert.Equal(t, "grpc-go/1.75.0", call.Arguments.Get(5))
+	assert.Equal(t, "acme", call.Arguments.Get(6))
+	assert.InDelta(t, time.Minute.Milliseconds(), call.Arguments.Get(7), float64(time.Second.Milliseconds()))
+	assert.Equal(t, proto.Size(wrapperspb.String("ping")), call.Arguments.Get(8))
+	assert.Equal(t, proto.Size(wrapperspb.String("pong")), call.Arguments.Get(9))
+}
+
+func TestLoggingInterceptor_Handle_PrincipalFunc(t *testing.T) {
+	logger := &MockLogger{}
+	logger.On("Infof", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+
+	interceptor := internal.NewLoggingInterceptor(logger, internal.WithPrincipalFunc(func(ctx context.Context) string {
+		return "user:42"
+	}))
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "success", nil
+	}
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}
+	_, err := interceptor.Handle(context.Background(), nil, info, handler)
+	assert.NoError(t, err)
+
+	call := logger.Calls[0]
+	assert.Equal(t, "[gRPC] method=%s duration=%s status=OK identity=%v", call.Arguments.String(0))
+	assert.Equal(t, "user:42", call.Arguments.Get(3))
+}
+
+func TestLoggingInterceptor_HandleStream_CallerFields(t *testing.T) {
+	logger := &MockLogger{}
+	logger.On("Infof", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+
+	interceptor := internal.NewStreamLoggingInterceptor(logger)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("user-agent", "grpc-go/1.75.0"))
+	stream := &MockServerStream{ctx: ctx}
+
+	info := &grpc.StreamServerInfo{FullMethod: "/test.Service/Stream", IsServerStream: true}
+	err := interceptor.HandleStream(nil, stream, info, func(srv any, ss grpc.ServerStream) error {
+		return nil
+	})
+	assert.NoError(t, err)
+
+	call := logger.Calls[0]
+	assert.True(t, strings.HasSuffix(call.Arguments.String(0), "status=OK user_agent=%v"))
+	assert.Equal(t, "grpc-go/1.75.0", call.Arguments.Get(8))
+}
+
+func TestLoggingInterceptor_Handle_RequestID(t *testing.T) {
+	logger := &MockLogger{}
+	logger.On("Infof", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+
+	interceptor := internal.NewLoggingInterceptor(logger)
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "success", nil
+	}
+
+	ctx := internal.ContextWithRequestID(context.Background(), "req-1")
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}
+	_, err := interceptor.Handle(ctx, nil, info, handler)
+	assert.NoError(t, err)
+
+	call := logger.Calls[0]
+	assert.Equal(t, "[gRPC] method=%s duration=%s status=OK request_id=%v", call.Arguments.String(0))
+	assert.Equal(t, "req-1", call.Arguments.Get(3))
+}
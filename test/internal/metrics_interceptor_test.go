@@ -0,0 +1,177 @@
+package internal_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/itsLeonB/gerpc/internal"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// gatherMetric returns the metric of family name matching labels
+func gatherMetric(t *testing.T, registry *prometheus.Registry, name string, labels map[string]string) *dto.Metric {
+	families, err := registry.Gather()
+	require.NoError(t, err)
+
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+	metrics:
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if value, ok := labels[label.GetName()]; ok && value != label.GetValue() {
+					continue metrics
+				}
+			}
+			return metric
+		}
+	}
+	t.Fatalf("metric %s%v not found", name, labels)
+	return nil
+}
+
+func TestMetricsInterceptor_Handle(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	interceptor := internal.NewMetricsInterceptor(registry)
+
+	method := "/test.Service/Method"
+	var inFlight float64
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		inFlight = gatherMetric(t, registry, "grpc_server_in_flight", map[string]string{"method": method}).GetGauge().GetValue()
+		return wrapperspb.String("pong"), nil
+	}
+
+	info := &grpc.UnaryServerInfo{FullMethod: method}
+	_, err := interceptor.Handle(context.Background(), wrapperspb.String("ping"), info, handler)
+	assert.NoError(t, err)
+
+	assert.Equal(t, float64(1), inFlight)
+	assert.Equal(t, float64(0), gatherMetric(t, registry, "grpc_server_in_flight", map[string]string{"method": method}).GetGauge().GetValue())
+	assert.Equal(t, float64(1), gatherMetric(t, registry, "grpc_server_handled_total", map[string]string{"method": method, "code": "OK"}).GetCounter().GetValue())
+	assert.Equal(t, uint64(1), gatherMetric(t, registry, "grpc_server_handling_seconds", map[string]string{"method": method}).GetHistogram().GetSampleCount())
+
+	received := gatherMetric(t, registry, "grpc_server_msg_size_bytes", map[string]string{"method": method, "direction": "received"}).GetHistogram()
+	assert.Equal(t, uint64(1), received.GetSampleCount())
+	assert.Equal(t, float64(proto.Size(wrapperspb.String("ping"))), received.GetSampleSum())
+	sent := gatherMetric(t, registry, "grpc_server_msg_size_bytes", map[string]string{"method": method, "direction": "sent"}).GetHistogram()
+	assert.Equal(t, uint64(1), sent.GetSampleCount())
+}
+
+func TestMetricsInterceptor_Handle_Error(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	interceptor := internal.NewMetricsInterceptor(registry)
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.NotFound, "missing")
+	}
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}
+	_, err := interceptor.Handle(context.Background(), "plain", info, handler)
+	assert.Error(t, err)
+
+	assert.Equal(t, float64(1), gatherMetric(t, registry, "grpc_server_handled_total", map[string]string{"code": "NotFound"}).GetCounter().GetValue())
+	// Neither the non-proto request nor the missing response are observed
+	assert.Equal(t, 0, testutil.CollectAndCount(registry, "grpc_server_msg_size_bytes"))
+}
+
+func TestMetricsInterceptor_HandleStream(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	interceptor := internal.NewStreamMetricsInterceptor(registry)
+
+	method := "/test.Service/Stream"
+	stream := &MockServerStream{}
+	info := &grpc.StreamServerInfo{FullMethod: method, IsServerStream: true}
+
+	err := interceptor.HandleStream(nil, stream, info, func(srv any, ss grpc.ServerStream) error {
+		for range 3 {
+			if err := ss.SendMsg(wrapperspb.String("chunk")); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, float64(1), gatherMetric(t, registry, "grpc_server_handled_total", map[string]string{"method": method, "code": "OK"}).GetCounter().GetValue())
+	sent := gatherMetric(t, registry, "grpc_server_msg_size_bytes", map[string]string{"method": method, "direction": "sent"}).GetHistogram()
+	assert.Equal(t, uint64(3), sent.GetSampleCount())
+}
+
+func TestMetricsInterceptor_SharedRegistry(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	unary := internal.NewMetricsInterceptor(registry)
+	stream := internal.NewStreamMetricsInterceptor(registry)
+
+	_, err := unary.Handle(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"},
+		func(ctx context.Context, req interface{}) (interface{}, error) { return nil, nil })
+	assert.NoError(t, err)
+	err = stream.HandleStream(nil, &MockServerStream{}, &grpc.StreamServerInfo{FullMethod: "/test.Service/Stream"},
+		func(srv any, ss grpc.ServerStream) error { return nil })
+	assert.NoError(t, err)
+
+	assert.Equal(t, 2, testutil.CollectAndCount(registry, "grpc_server_handled_total"))
+}
+
+func TestMetricsInterceptor_WithLatencyBuckets(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	interceptor := internal.NewMetricsInterceptor(registry,
+		internal.WithLatencyBuckets(0.5, 1),
+		internal.WithMessageSizeBuckets(100),
+	)
+
+	_, err := interceptor.Handle(context.Background(), wrapperspb.String("ping"), &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"},
+		func(ctx context.Context, req interface{}) (interface{}, error) { return nil, nil })
+	assert.NoError(t, err)
+
+	latency := gatherMetric(t, registry, "grpc_server_handling_seconds", nil).GetHistogram()
+	assert.Len(t, latency.GetBucket(), 2)
+	assert.Equal(t, 0.5, latency.GetBucket()[0].GetUpperBound())
+	size := gatherMetric(t, registry, "grpc_server_msg_size_bytes", nil).GetHistogram()
+	assert.Len(t, size.GetBucket(), 1)
+}
+
+func TestMetricsInterceptor_Panic(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	unary := internal.NewMetricsInterceptor(registry)
+	stream := internal.NewStreamMetricsInterceptor(registry)
+
+	assert.PanicsWithValue(t, "boom", func() {
+		_, _ = unary.Handle(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"},
+			func(ctx context.Context, req interface{}) (interface{}, error) { panic("boom") })
+	})
+	assert.PanicsWithValue(t, "boom", func() {
+		_ = stream.HandleStream(nil, &MockServerStream{}, &grpc.StreamServerInfo{FullMethod: "/test.Service/Stream"},
+			func(srv any, ss grpc.ServerStream) error { panic("boom") })
+	})
+
+	for _, method := range []string{"/test.Service/Method", "/test.Service/Stream"} {
+		assert.Equal(t, float64(0), gatherMetric(t, registry, "grpc_server_in_flight", map[string]string{"method": method}).GetGauge().GetValue())
+		assert.Equal(t, float64(1), gatherMetric(t, registry, "grpc_server_handled_total", map[string]string{"method": method, "code": "Internal"}).GetCounter().GetValue())
+	}
+}
+
+func TestMetricsInterceptor_BucketMismatch(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	internal.NewMetricsInterceptor(registry, internal.WithLatencyBuckets(0.5, 1))
+
+	// Same buckets share the histogram
+	assert.NotPanics(t, func() {
+		internal.NewStreamMetricsInterceptor(registry, internal.WithLatencyBuckets(0.5, 1))
+	})
+	assert.PanicsWithValue(t, "histogram grpc_server_handling_seconds already registered with buckets [0.5 1], cannot use [2]", func() {
+		internal.NewStreamMetricsInterceptor(registry, internal.WithLatencyBuckets(2))
+	})
+	assert.Panics(t, func() {
+		internal.NewStreamMetricsInterceptor(registry, internal.WithLatencyBuckets(0.5, 1), internal.WithMessageSizeBuckets(100))
+	})
+}
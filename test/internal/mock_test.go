@@ -1,6 +1,13 @@
 package internal_test
 
-import "github.com/stretchr/testify/mock"
+import (
+	"context"
+	"log/slog"
+
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
 
 type MockLogger struct {
 	mock.Mock
@@ -26,3 +33,45 @@ func (m *MockLogger) Fatal(args ...interface{}) { m.Called(args...) }
 func (m *MockLogger) Fatalf(format string, args ...interface{}) {
 	m.Called(append([]interface{}{format}, args...)...)
 }
+
+type MockServerStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	sendErr error
+	recvErr error
+	sent    []any
+	header  metadata.MD
+}
+
+func (m *MockServerStream) Context() context.Context {
+	if m.ctx == nil {
+		return context.Background()
+	}
+	return m.ctx
+}
+
+func (m *MockServerStream) SendMsg(msg any) error {
+	if m.sendErr != nil {
+		return m.sendErr
+	}
+	m.sent = append(m.sent, msg)
+	return nil
+}
+
+func (m *MockServerStream) RecvMsg(any) error {
+	return m.recvErr
+}
+
+func (m *MockServerStream) SetHeader(md metadata.MD) error {
+	m.header = metadata.Join(m.header, md)
+	return nil
+}
+
+// MockLeveledLogger is a MockLogger that also emits structured records
+type MockLeveledLogger struct {
+	MockLogger
+}
+
+func (m *MockLeveledLogger) Log(ctx context.Context, level slog.Level, msg string, args ...any) {
+	m.Called(ctx, level, msg, args)
+}
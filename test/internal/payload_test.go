@@ -0,0 +1,172 @@
+package internal_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/itsLeonB/gerpc/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// newUserMessage builds a dynamic message shaped like:
+//
+//	message Card { string number = 1; string holder = 2; }
+//	message User {
+//	  string name = 1;
+//	  string password = 2;
+//	  Card card = 3;
+//	  string token = 4 [debug_redact = true];
+//	}
+func newUserMessage(t *testing.T) *dynamicpb.Message {
+	str := descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum()
+	msgType := descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+	optional := descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()
+
+	file, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:    proto.String("payload_test.proto"),
+		Package: proto.String("test"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("Card"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					{Name: proto.String("number"), Number: proto.Int32(1), Type: str, Label: optional, JsonName: proto.String("number")},
+					{Name: proto.String("holder"), Number: proto.Int32(2), Type: str, Label: optional, JsonName: proto.String("holder")},
+				},
+			},
+			{
+				Name: proto.String("User"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					{Name: proto.String("name"), Number: proto.Int32(1), Type: str, Label: optional, JsonName: proto.String("name")},
+					{Name: proto.String("password"), Number: proto.Int32(2), Type: str, Label: optional, JsonName: proto.String("password")},
+					{Name: proto.String("card"), Number: proto.Int32(3), Type: msgType, TypeName: proto.String(".test.Card"), Label: optional, JsonName: proto.String("card")},
+					{
+						Name: proto.String("token"), Number: proto.Int32(4), Type: str, Label: optional, JsonName: proto.String("token"),
+						Options: &descriptorpb.FieldOptions{DebugRedact: proto.Bool(true)},
+					},
+				},
+			},
+		},
+	}, nil)
+	require.NoError(t, err)
+
+	userDesc := file.Messages().ByName("User")
+	user := dynamicpb.NewMessage(userDesc)
+	user.Set(userDesc.Fields().ByName("name"), protoreflect.ValueOfString("alice"))
+	user.Set(userDesc.Fields().ByName("password"), protoreflect.ValueOfString("hunter2"))
+	user.Set(userDesc.Fields().ByName("token"), protoreflect.ValueOfString("secret-token"))
+
+	cardDesc := file.Messages().ByName("Card")
+	card := dynamicpb.NewMessage(cardDesc)
+	card.Set(cardDesc.Fields().ByName("number"), protoreflect.ValueOfString("4111111111111111"))
+	card.Set(cardDesc.Fields().ByName("holder"), protoreflect.ValueOfString("Alice"))
+	user.Set(userDesc.Fields().ByName("card"), protoreflect.ValueOfMessage(card))
+
+	return user
+}
+
+func TestLoggingInterceptor_Handle_PayloadRedaction(t *testing.T) {
+	logger := &MockLogger{}
+	logger.On("Infof", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+
+	interceptor := internal.NewLoggingInterceptor(logger,
+		internal.WithPayloadLogging(0),
+		internal.WithRedactedFields("password", "card.number"),
+	)
+
+	user := newUserMessage(t)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return req, nil
+	}
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}
+	resp, err := interceptor.Handle(context.Background(), user, info, handler)
+	assert.NoError(t, err)
+	assert.Same(t, user, resp)
+
+	call := logger.Calls[0]
+	assert.Equal(t, "[gRPC] method=%s duration=%s status=OK request_bytes=%v response_bytes=%v request=%v response=%v", call.Arguments.String(0))
+
+	request := call.Arguments.Get(5).(string)
+	assert.Contains(t, request, `"name":"alice"`)
+	assert.Contains(t, request, `"holder":"Alice"`)
+	assert.Contains(t, request, `"password":"[REDACTED]"`)
+	assert.Contains(t, request, `"number":"[REDACTED]"`)
+	assert.Contains(t, request, `"token":"[REDACTED]"`)
+	assert.NotContains(t, request, "hunter2")
+	assert.NotContains(t, request, "4111111111111111")
+	assert.NotContains(t, request, "secret-token")
+
+	// The handler's messages are left untouched
+	assert.Equal(t, "hunter2", user.Get(user.Descriptor().Fields().ByName("password")).String())
+}
+
+func TestLoggingInterceptor_Handle_PayloadTruncation(t *testing.T) {
+	logger := &MockLogger{}
+	logger.On("Errorf", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+
+	interceptor := internal.NewLoggingInterceptor(logger, internal.WithPayloadLogging(10))
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, assert.AnError
+	}
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}
+	_, err := interceptor.Handle(context.Background(), newUserMessage(t), info, handler)
+	assert.Error(t, err)
+
+	call := logger.Calls[0]
+	assert.True(t, strings.HasSuffix(call.Arguments.String(0), " request=%v"))
+
+	request := call.Arguments.Get(7).(string)
+	assert.True(t, strings.HasSuffix(request, "...(truncated)"))
+	assert.Len(t, request, 10+len("...(truncated)"))
+}
+
+func TestLoggingInterceptor_Handle_PayloadTruncationUTF8(t *testing.T) {
+	logger := &MockLogger{}
+	logger.On("Errorf", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+
+	// The 3rd byte of "héllo" rendered as JSON falls inside "é"
+	interceptor := internal.NewLoggingInterceptor(logger, internal.WithPayloadLogging(3))
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, assert.AnError
+	}
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}
+	_, err := interceptor.Handle(context.Background(), wrapperspb.String("héllo"), info, handler)
+	assert.Error(t, err)
+
+	request := logger.Calls[0].Arguments.Get(7).(string)
+	assert.True(t, utf8.ValidString(request), request)
+	assert.Equal(t, `"h...(truncated)`, request)
+}
+
+func TestLoggingInterceptor_Handle_NonProtoPayload(t *testing.T) {
+	logger := &MockLogger{}
+	logger.On("Infof", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+
+	interceptor := internal.NewLoggingInterceptor(logger, internal.WithPayloadLogging(0))
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "plain", nil
+	}
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}
+	_, err := interceptor.Handle(context.Background(), "plain", info, handler)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "<non-proto string>", logger.Calls[0].Arguments.Get(3))
+}
@@ -0,0 +1,59 @@
+package internal_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/itsLeonB/gerpc/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRecoveryInterceptor_Handle_Panic(t *testing.T) {
+	logger := &MockLogger{}
+	logger.On("Error", mock.Anything).Return()
+	logger.On("Errorf", mock.Anything, mock.Anything).Return()
+
+	interceptor := internal.NewRecoveryInterceptor(logger)
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		panic("boom")
+	}
+
+	_, err := interceptor.Handle(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}, handler)
+
+	assert.Equal(t, codes.Internal, status.Code(err))
+	logger.AssertCalled(t, "Errorf", "Panic value: %v", "boom")
+}
+
+func TestRecoveryInterceptor_Handle_PassesErrorsThrough(t *testing.T) {
+	interceptor := internal.NewRecoveryInterceptor(&MockLogger{})
+
+	handlerErr := errors.New("plain error")
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, handlerErr
+	}
+
+	_, err := interceptor.Handle(context.Background(), nil, &grpc.UnaryServerInfo{}, handler)
+
+	assert.Equal(t, handlerErr, err)
+}
+
+func TestRecoveryInterceptor_HandleStream_Panic(t *testing.T) {
+	logger := &MockLogger{}
+	logger.On("Error", mock.Anything).Return()
+	logger.On("Errorf", mock.Anything, mock.Anything).Return()
+
+	interceptor := internal.NewStreamRecoveryInterceptor(logger)
+
+	err := interceptor.HandleStream(nil, &MockServerStream{}, &grpc.StreamServerInfo{FullMethod: "/test.Service/Stream"}, func(srv any, ss grpc.ServerStream) error {
+		panic("boom")
+	})
+
+	assert.Equal(t, codes.Internal, status.Code(err))
+	logger.AssertCalled(t, "Errorf", "gRPC method: %s", "/test.Service/Stream")
+}
@@ -0,0 +1,104 @@
+package internal_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/itsLeonB/gerpc/internal"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func handleRequestID(t *testing.T, ctx context.Context, opts ...internal.RequestIDInterceptorOption) string {
+	interceptor := internal.NewRequestIDInterceptor(opts...)
+
+	var id string
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		id = internal.RequestIDFromContext(ctx)
+		return nil, nil
+	}
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}
+	_, err := interceptor.Handle(ctx, nil, info, handler)
+	assert.NoError(t, err)
+	return id
+}
+
+func TestRequestIDInterceptor_Handle_UsesIncomingID(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(internal.RequestIDKey, "req-1"))
+
+	assert.Equal(t, "req-1", handleRequestID(t, ctx))
+}
+
+func TestRequestIDInterceptor_Handle_GeneratesID(t *testing.T) {
+	id := handleRequestID(t, context.Background())
+
+	assert.Len(t, id, 36)
+	assert.NotEqual(t, id, handleRequestID(t, context.Background()))
+}
+
+func TestRequestIDInterceptor_Handle_ReplacesInvalidID(t *testing.T) {
+	generate := func() string { return "generated" }
+
+	for _, incoming := range []string{"has space", "line\nbreak", strings.Repeat("a", 129)} {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(internal.RequestIDKey, incoming))
+		assert.Equal(t, "generated", handleRequestID(t, ctx, internal.WithRequestIDGenerator(generate)), incoming)
+	}
+}
+
+func TestRequestIDInterceptor_HandleStream(t *testing.T) {
+	interceptor := internal.NewStreamRequestIDInterceptor(internal.WithRequestIDGenerator(func() string {
+		return "generated"
+	}))
+
+	stream := &MockServerStream{}
+	info := &grpc.StreamServerInfo{FullMethod: "/test.Service/Stream"}
+
+	var id string
+	err := interceptor.HandleStream(nil, stream, info, func(srv any, ss grpc.ServerStream) error {
+		id = internal.RequestIDFromContext(ss.Context())
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "generated", id)
+	assert.Equal(t, []string{"generated"}, stream.header.Get(internal.RequestIDKey))
+}
+
+func TestClientRequestIDInterceptor(t *testing.T) {
+	var outgoing metadata.MD
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		outgoing, _ = metadata.FromOutgoingContext(ctx)
+		return nil
+	}
+
+	err := internal.ClientRequestIDInterceptor(context.Background(), "/test.Service/Method", nil, nil, nil, invoker)
+	assert.NoError(t, err)
+	assert.Empty(t, outgoing.Get(internal.RequestIDKey))
+
+	ctx := internal.ContextWithRequestID(context.Background(), "req-1")
+	err = internal.ClientRequestIDInterceptor(ctx, "/test.Service/Method", nil, nil, nil, invoker)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"req-1"}, outgoing.Get(internal.RequestIDKey))
+
+	// An ID set explicitly by the caller is kept
+	ctx = metadata.AppendToOutgoingContext(ctx, internal.RequestIDKey, "explicit")
+	err = internal.ClientRequestIDInterceptor(ctx, "/test.Service/Method", nil, nil, nil, invoker)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"explicit"}, outgoing.Get(internal.RequestIDKey))
+}
+
+func TestStreamClientRequestIDInterceptor(t *testing.T) {
+	var outgoing metadata.MD
+	streamer := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		outgoing, _ = metadata.FromOutgoingContext(ctx)
+		return nil, nil
+	}
+
+	ctx := internal.ContextWithRequestID(context.Background(), "req-1")
+	_, err := internal.StreamClientRequestIDInterceptor(ctx, &grpc.StreamDesc{}, nil, "/test.Service/Stream", streamer)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"req-1"}, outgoing.Get(internal.RequestIDKey))
+}
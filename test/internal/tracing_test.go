@@ -0,0 +1,123 @@
+package internal_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/itsLeonB/gerpc/internal"
+	"github.com/rotisserie/eris"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc"
+)
+
+// withSpan runs fn inside a recording span and returns the span once ended
+func withSpan(t *testing.T, fn func(ctx context.Context)) sdktrace.ReadOnlySpan {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+	ctx, span := provider.Tracer("test").Start(context.Background(), "rpc")
+	fn(ctx)
+	span.End()
+
+	spans := exporter.GetSpans().Snapshots()
+	require.Len(t, spans, 1)
+	return spans[0]
+}
+
+func eventAttributes(span sdktrace.ReadOnlySpan, name string) map[attribute.Key]string {
+	for _, event := range span.Events() {
+		if event.Name != name {
+			continue
+		}
+		attrs := make(map[attribute.Key]string, len(event.Attributes))
+		for _, attr := range event.Attributes {
+			attrs[attr.Key] = attr.Value.Emit()
+		}
+		return attrs
+	}
+	return nil
+}
+
+func TestErrorInterceptor_Handle_RecordsMaskedErrorOnSpan(t *testing.T) {
+	logger := &MockLogger{}
+	logger.On("Errorf", mock.Anything, mock.Anything).Return()
+	logger.On("Error", mock.Anything).Return()
+
+	interceptor := internal.NewErrorInterceptor(logger)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, eris.Wrap(errors.New("database unavailable"), "error loading user")
+	}
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}
+	span := withSpan(t, func(ctx context.Context) {
+		_, err := interceptor.Handle(ctx, nil, info, handler)
+		assert.Error(t, err)
+	})
+
+	attrs := eventAttributes(span, "exception")
+	require.NotNil(t, attrs)
+	assert.Contains(t, attrs["exception.message"], "database unavailable")
+	assert.Contains(t, attrs["exception.stacktrace"], "error loading user")
+	assert.Contains(t, attrs["exception.stacktrace"], "tracing_test.go")
+}
+
+func TestErrorInterceptor_Handle_RecordsPanicOnSpan(t *testing.T) {
+	logger := &MockLogger{}
+	logger.On("Errorf", mock.Anything, mock.Anything).Return()
+	logger.On("Error", mock.Anything).Return()
+
+	interceptor := internal.NewErrorInterceptor(logger)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		panic("boom")
+	}
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}
+	span := withSpan(t, func(ctx context.Context) {
+		_, err := interceptor.Handle(ctx, nil, info, handler)
+		assert.Error(t, err)
+	})
+
+	attrs := eventAttributes(span, "panic")
+	require.NotNil(t, attrs)
+	assert.Equal(t, "string_panic", attrs["panic.classification"])
+	assert.Equal(t, "boom", attrs["panic.value"])
+	assert.Equal(t, "string", attrs["panic.type"])
+	assert.Contains(t, attrs["exception.stacktrace"], "tracing_test.go")
+}
+
+func TestSpanAnnotator_Handle(t *testing.T) {
+	annotator := internal.NewSpanAnnotator()
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, nil
+	}
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}
+	span := withSpan(t, func(ctx context.Context) {
+		_, err := annotator.Handle(internal.ContextWithRequestID(ctx, "req-1"), nil, info, handler)
+		assert.NoError(t, err)
+	})
+
+	assert.Contains(t, span.Attributes(), attribute.String(internal.RequestIDAttribute, "req-1"))
+}
+
+func TestSpanAnnotator_HandleStream(t *testing.T) {
+	annotator := internal.NewSpanAnnotator()
+	info := &grpc.StreamServerInfo{FullMethod: "/test.Service/Stream"}
+
+	span := withSpan(t, func(ctx context.Context) {
+		stream := &MockServerStream{ctx: internal.ContextWithRequestID(ctx, "req-1")}
+		err := annotator.HandleStream(nil, stream, info, func(srv any, ss grpc.ServerStream) error {
+			return nil
+		})
+		assert.NoError(t, err)
+	})
+
+	assert.Contains(t, span.Attributes(), attribute.String(internal.RequestIDAttribute, "req-1"))
+}
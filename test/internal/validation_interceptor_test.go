@@ -0,0 +1,249 @@
+package internal_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	"github.com/go-playground/validator/v10"
+	"github.com/itsLeonB/gerpc/internal"
+	"github.com/itsLeonB/ungerr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+type createUserRequest struct {
+	Email string `validate:"required,email"`
+	Age   int    `validate:"gte=18"`
+}
+
+type selfValidatingRequest struct {
+	err error
+}
+
+func (r selfValidatingRequest) Validate() error { return r.err }
+
+// pgvFieldError mimics the field errors generated by protoc-gen-validate
+type pgvFieldError struct {
+	field, reason string
+}
+
+func (e pgvFieldError) Field() string  { return e.field }
+func (e pgvFieldError) Reason() string { return e.reason }
+func (e pgvFieldError) Error() string  { return fmt.Sprintf("invalid %s: %s", e.field, e.reason) }
+
+// pgvMultiError mimics the MultiError returned by protoc-gen-validate ValidateAll methods
+type pgvMultiError []error
+
+func (m pgvMultiError) AllErrors() []error { return m }
+func (m pgvMultiError) Error() string      { return errors.Join(m...).Error() }
+
+type pgvRequest struct{}
+
+func (pgvRequest) Validate() error { return pgvFieldError{"name", "value is required"} }
+
+func (pgvRequest) ValidateAll() error {
+	return pgvMultiError{
+		pgvFieldError{"name", "value is required"},
+		pgvFieldError{"email", "value must be a valid email address"},
+	}
+}
+
+// newRuledMessage builds a dynamic message with a protovalidate rule:
+//
+//	message User { string name = 1 [(buf.validate.field).string.min_len = 3]; }
+func newRuledMessage(t *testing.T, name string) *dynamicpb.Message {
+	options := &descriptorpb.FieldOptions{}
+	proto.SetExtension(options, validate.E_Field, &validate.FieldRules{
+		Type: &validate.FieldRules_String_{String_: &validate.StringRules{MinLen: proto.Uint64(3)}},
+	})
+
+	file, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:    proto.String("validation_test.proto"),
+		Package: proto.String("test"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("User"),
+			Field: []*descriptorpb.FieldDescriptorProto{{
+				Name:     proto.String("name"),
+				Number:   proto.Int32(1),
+				Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+				Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+				JsonName: proto.String("name"),
+				Options:  options,
+			}},
+		}},
+	}, nil)
+	require.NoError(t, err)
+
+	desc := file.Messages().ByName("User")
+	msg := dynamicpb.NewMessage(desc)
+	msg.Set(desc.Fields().ByName("name"), protoreflect.ValueOfString(name))
+	return msg
+}
+
+func badRequest(st *status.Status) *errdetails.BadRequest {
+	for _, detail := range st.Details() {
+		if br, ok := detail.(*errdetails.BadRequest); ok {
+			return br
+		}
+	}
+	return nil
+}
+
+func handleValidation(req any, opts ...internal.ValidationInterceptorOption) (bool, error) {
+	interceptor := internal.NewValidationInterceptor(opts...)
+
+	called := false
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		called = true
+		return nil, nil
+	}
+
+	_, err := interceptor.Handle(context.Background(), req, &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}, handler)
+	return called, err
+}
+
+func TestValidationInterceptor_Handle_Valid(t *testing.T) {
+	for _, req := range []any{
+		&createUserRequest{Email: "alice@example.com", Age: 30},
+		selfValidatingRequest{},
+		newRuledMessage(t, "alice"),
+		"not a struct",
+		nil,
+	} {
+		called, err := handleValidation(req)
+		assert.NoError(t, err)
+		assert.True(t, called)
+	}
+}
+
+func TestValidationInterceptor_Handle_StructTags(t *testing.T) {
+	called, err := handleValidation(&createUserRequest{Email: "not-an-email", Age: 12})
+	assert.False(t, called)
+
+	st := status.Convert(err)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+
+	br := badRequest(st)
+	require.NotNil(t, br)
+	require.Len(t, br.GetFieldViolations(), 2)
+	assert.Equal(t, "createUserRequest.Email", br.GetFieldViolations()[0].GetField())
+	assert.Equal(t, "email", br.GetFieldViolations()[0].GetReason())
+	assert.Equal(t, "createUserRequest.Age", br.GetFieldViolations()[1].GetField())
+	assert.Equal(t, "gte", br.GetFieldViolations()[1].GetReason())
+}
+
+func TestValidationInterceptor_Handle_Protovalidate(t *testing.T) {
+	called, err := handleValidation(newRuledMessage(t, "al"))
+	assert.False(t, called)
+
+	st := status.Convert(err)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+
+	br := badRequest(st)
+	require.NotNil(t, br)
+	require.Len(t, br.GetFieldViolations(), 1)
+	assert.Equal(t, "name", br.GetFieldViolations()[0].GetField())
+	assert.Equal(t, "string.min_len", br.GetFieldViolations()[0].GetReason())
+	assert.NotEmpty(t, br.GetFieldViolations()[0].GetDescription())
+}
+
+func TestValidationInterceptor_Handle_ValidateMethod(t *testing.T) {
+	called, err := handleValidation(selfValidatingRequest{errors.New("start must be before end")})
+	assert.False(t, called)
+
+	st := status.Convert(err)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+
+	br := badRequest(st)
+	require.NotNil(t, br)
+	require.Len(t, br.GetFieldViolations(), 1)
+	assert.Empty(t, br.GetFieldViolations()[0].GetField())
+	assert.Equal(t, "start must be before end", br.GetFieldViolations()[0].GetDescription())
+}
+
+func TestValidationInterceptor_Handle_ValidateAll(t *testing.T) {
+	_, err := handleValidation(pgvRequest{})
+
+	br := badRequest(status.Convert(err))
+	require.NotNil(t, br)
+	require.Len(t, br.GetFieldViolations(), 2)
+	assert.Equal(t, "name", br.GetFieldViolations()[0].GetField())
+	assert.Equal(t, "value is required", br.GetFieldViolations()[0].GetDescription())
+	assert.Equal(t, "email", br.GetFieldViolations()[1].GetField())
+}
+
+func TestValidationInterceptor_Handle_ValidateMethodAppError(t *testing.T) {
+	_, err := handleValidation(selfValidatingRequest{ungerr.ConflictError("user already exists")})
+
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+}
+
+func TestValidationInterceptor_Handle_ValidateMethodValidatorErrors(t *testing.T) {
+	validationErr := validator.New().Struct(&createUserRequest{})
+	_, err := handleValidation(selfValidatingRequest{validationErr}, internal.WithStructValidator(nil))
+
+	br := badRequest(status.Convert(err))
+	require.NotNil(t, br)
+	assert.Equal(t, "required", br.GetFieldViolations()[0].GetReason())
+}
+
+func TestValidationInterceptor_Handle_DisabledValidators(t *testing.T) {
+	called, err := handleValidation(&createUserRequest{}, internal.WithStructValidator(nil))
+	assert.NoError(t, err)
+	assert.True(t, called)
+
+	called, err = handleValidation(newRuledMessage(t, "al"), internal.WithProtoValidator(nil))
+	assert.NoError(t, err)
+	assert.True(t, called)
+}
+
+// recvStream returns queued messages from RecvMsg, then io.EOF
+type recvStream struct {
+	MockServerStream
+	messages []*createUserRequest
+}
+
+func (s *recvStream) RecvMsg(m any) error {
+	if len(s.messages) == 0 {
+		return io.EOF
+	}
+	*m.(*createUserRequest) = *s.messages[0]
+	s.messages = s.messages[1:]
+	return nil
+}
+
+func TestValidationInterceptor_HandleStream(t *testing.T) {
+	interceptor := internal.NewStreamValidationInterceptor()
+	stream := &recvStream{messages: []*createUserRequest{
+		{Email: "alice@example.com", Age: 30},
+		{Email: "bob", Age: 30},
+	}}
+
+	received := 0
+	err := interceptor.HandleStream(nil, stream, &grpc.StreamServerInfo{FullMethod: "/test.Service/Stream"}, func(srv any, ss grpc.ServerStream) error {
+		for {
+			if err := ss.RecvMsg(&createUserRequest{}); err != nil {
+				return err
+			}
+			received++
+		}
+	})
+
+	assert.Equal(t, 1, received)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.NotNil(t, badRequest(status.Convert(err)))
+}
@@ -0,0 +1,124 @@
+package gerpc_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/itsLeonB/ezutil/v2"
+	"github.com/itsLeonB/gerpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+var _ ezutil.Logger = (*gerpc.SlogLogger)(nil)
+
+func newJSONLogger() (*gerpc.SlogLogger, *bytes.Buffer) {
+	buf := &bytes.Buffer{}
+	handler := slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug})
+	return gerpc.NewSlogLogger(slog.New(handler)), buf
+}
+
+func decodeRecords(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	var records []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var record map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestSlogLogger_PrintfMethods(t *testing.T) {
+	logger, buf := newJSONLogger()
+
+	logger.Infof("server started at: %s", ":8080")
+	logger.Warn("careful")
+
+	records := decodeRecords(t, buf)
+	assert.Len(t, records, 2)
+	assert.Equal(t, "INFO", records[0]["level"])
+	assert.Equal(t, "server started at: :8080", records[0]["msg"])
+	assert.Equal(t, "WARN", records[1]["level"])
+	assert.Equal(t, "careful", records[1]["msg"])
+}
+
+func TestSlogLogger_LoggingInterceptor(t *testing.T) {
+	logger, buf := newJSONLogger()
+	interceptor := gerpc.NewLoggingInterceptor(logger)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-request-id", "req-1"))
+	ctx = peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 5000}})
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}
+
+	_, err := interceptor(ctx, nil, info, func(ctx context.Context, req any) (any, error) {
+		return nil, status.Error(codes.NotFound, "missing")
+	})
+	assert.Error(t, err)
+
+	records := decodeRecords(t, buf)
+	assert.Len(t, records, 1)
+	record := records[0]
+	assert.Equal(t, "ERROR", record["level"])
+	assert.Equal(t, "/test.Service/Method", record["method"])
+	assert.Equal(t, "NotFound", record["code"])
+	assert.Equal(t, "10.0.0.1:5000", record["peer"])
+	assert.Equal(t, "req-1", record["request_id"])
+	assert.Equal(t, "missing", record["status_message"])
+	assert.IsType(t, float64(0), record["duration_ms"])
+}
+
+func TestSlogLogger_LoggingInterceptorCallerFields(t *testing.T) {
+	logger, buf := newJSONLogger()
+	interceptor := gerpc.NewLoggingInterceptor(logger,
+		gerpc.WithMetadataKeys("x-tenant-id"),
+		gerpc.WithPrincipalFunc(func(ctx context.Context) string { return "user:42" }),
+	)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		"user-agent", "grpc-go/1.75.0",
+		"x-tenant-id", "acme",
+		"authorization", "Bearer secret",
+	))
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}
+
+	_, err := interceptor(ctx, nil, info, func(ctx context.Context, req any) (any, error) {
+		return nil, nil
+	})
+	assert.NoError(t, err)
+
+	records := decodeRecords(t, buf)
+	assert.Len(t, records, 1)
+	record := records[0]
+	assert.Equal(t, "user:42", record["identity"])
+	assert.Equal(t, "grpc-go/1.75.0", record["user_agent"])
+	assert.Equal(t, "acme", record["metadata.x-tenant-id"])
+	assert.NotContains(t, buf.String(), "Bearer secret")
+	assert.NotContains(t, record, "deadline_remaining_ms")
+}
+
+func TestSlogLogger_ErrorInterceptor(t *testing.T) {
+	logger, buf := newJSONLogger()
+	interceptor := gerpc.NewErrorInterceptor(logger)
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}
+	_, err := interceptor(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+		panic("boom")
+	})
+	assert.Equal(t, codes.Internal, status.Code(err))
+
+	records := decodeRecords(t, buf)
+	assert.Len(t, records, 1)
+	assert.Equal(t, "panic recovered in gRPC handler", records[0]["msg"])
+	assert.Equal(t, "string_panic", records[0]["classification"])
+	assert.Equal(t, "/test.Service/Method", records[0]["method"])
+}